@@ -4,9 +4,11 @@
 package goschtalt
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/goschtalt/goschtalt/internal/print"
 	"github.com/goschtalt/goschtalt/pkg/decoder"
@@ -34,6 +36,38 @@ func (f BufferGetterFunc) Get(rn string, u Unmarshaler) ([]byte, error) {
 
 var _ BufferGetter = (*BufferGetterFunc)(nil)
 
+// BufferContextGetter provides the methods needed to get the buffer of bytes
+// while honoring the context of the compilation.
+type BufferContextGetter interface {
+	// GetContext is called each time the configuration is compiled.  The ctx
+	// is canceled if the compilation is canceled or the record times out.  The
+	// recordName and an Unmarshaler with the present stage of configuration and
+	// expanded variables are provided to assist.  A slice of bytes or an error
+	// is returned.
+	GetContext(ctx context.Context, recordName string, u Unmarshaler) ([]byte, error)
+}
+
+// The BufferContextGetterFunc type is an adapter to allow the use of ordinary
+// functions as BufferContextGetters. If f is a function with the appropriate
+// signature, BufferContextGetterFunc(f) is a BufferContextGetter that calls f.
+type BufferContextGetterFunc func(context.Context, string, Unmarshaler) ([]byte, error)
+
+// GetContext calls f(ctx, rn, u)
+func (f BufferContextGetterFunc) GetContext(ctx context.Context, rn string, u Unmarshaler) ([]byte, error) {
+	return f(ctx, rn, u)
+}
+
+var _ BufferContextGetter = (*BufferContextGetterFunc)(nil)
+
+// bufferGetterAdapter allows a BufferGetter to be used as a BufferContextGetter.
+type bufferGetterAdapter struct {
+	getter BufferGetter
+}
+
+func (b bufferGetterAdapter) GetContext(_ context.Context, rn string, u Unmarshaler) ([]byte, error) {
+	return b.getter.Get(rn, u)
+}
+
 // AddBuffer adds a buffer of bytes for inclusion when compiling the configuration.
 // The format of the bytes is determined by the extension of the recordName field.
 // The recordName field is also used for sorting this configuration value relative
@@ -47,8 +81,8 @@ func AddBuffer(recordName string, in []byte, opts ...BufferOption) Option {
 	return &buffer{
 		text:       print.P("AddBuffer", print.String(recordName), print.Bytes(in), print.LiteralStringers(opts)),
 		recordName: recordName,
-		getter: BufferGetterFunc(
-			func(_ string, _ Unmarshaler) ([]byte, error) {
+		getter: BufferContextGetterFunc(
+			func(_ context.Context, _ string, _ Unmarshaler) ([]byte, error) {
 				return in, nil
 			}),
 		opts: opts,
@@ -70,10 +104,34 @@ func AddBuffer(recordName string, in []byte, opts ...BufferOption) Option {
 //   - [BufferValueOption]
 //   - [GlobalOption]
 func AddBufferGetter(recordName string, getter BufferGetter, opts ...BufferOption) Option {
+	var ctxGetter BufferContextGetter
+	if getter != nil {
+		ctxGetter = bufferGetterAdapter{getter: getter}
+	}
+
 	return &buffer{
 		text:       print.P("AddBufferGetter", print.String(recordName), print.Obj(getter), print.LiteralStringers(opts)),
 		recordName: recordName,
 		opts:       opts,
+		getter:     ctxGetter,
+	}
+}
+
+// AddBufferContextGetter is the same as [AddBufferGetter]() except the getter
+// is provided a context that is canceled when the compilation is canceled or
+// when the record times out.
+//
+// See also: [CompileContext], [Timeout]
+//
+// Valid Option Types:
+//   - [BufferOption]
+//   - [BufferValueOption]
+//   - [GlobalOption]
+func AddBufferContextGetter(recordName string, getter BufferContextGetter, opts ...BufferOption) Option {
+	return &buffer{
+		text:       print.P("AddBufferContextGetter", print.String(recordName), print.Obj(getter), print.LiteralStringers(opts)),
+		recordName: recordName,
+		opts:       opts,
 		getter:     getter,
 	}
 }
@@ -86,7 +144,7 @@ type buffer struct {
 	recordName string
 
 	// The getter to use to get the value.
-	getter BufferGetter
+	getter BufferContextGetter
 
 	// Options that configure how this buffer is treated and processed.
 	// These options are in addition to any default settings set with
@@ -133,8 +191,21 @@ func (b buffer) String() string {
 
 // toTree converts an buffer into a meta.Object tree.  This will happen
 // during the compilation stage.
-func (b *buffer) toTree(delimiter string, u Unmarshaler, decoders *codecRegistry[decoder.Decoder]) (meta.Object, error) {
-	data, err := b.getter.Get(b.recordName, u)
+func (b *buffer) toTree(ctx context.Context, delimiter string, u Unmarshaler, decoders *codecRegistry[decoder.Decoder]) (meta.Object, error) {
+	var info bufferOptions
+	for _, opt := range b.opts {
+		if err := opt.bufferApply(&info); err != nil {
+			return meta.Object{}, err
+		}
+	}
+
+	if info.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, info.timeout)
+		defer cancel()
+	}
+
+	data, err := b.getter.GetContext(ctx, b.recordName, u)
 	if err != nil {
 		return meta.Object{}, err
 	}
@@ -146,13 +217,13 @@ func (b *buffer) toTree(delimiter string, u Unmarshaler, decoders *codecRegistry
 		return meta.Object{}, err
 	}
 
-	ctx := decoder.Context{
+	dctx := decoder.Context{
 		Filename:  b.recordName,
 		Delimiter: delimiter,
 	}
 
 	var tree meta.Object
-	err = dec.Decode(ctx, data, &tree)
+	err = dec.Decode(dctx, data, &tree)
 	if err != nil {
 		err = fmt.Errorf("decoder error for extension '%s' processing buffer '%s' %w %v",
 			ext, b.recordName, ErrDecoding, err) //nolint:errorlint
@@ -175,4 +246,5 @@ type BufferOption interface {
 
 type bufferOptions struct {
 	isDefault bool
+	timeout   time.Duration
 }
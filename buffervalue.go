@@ -5,6 +5,7 @@ package goschtalt
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/goschtalt/goschtalt/internal/print"
 )
@@ -45,3 +46,31 @@ func (o optionalAsDefault) valueApply(opts *valueOptions) error {
 func (o optionalAsDefault) String() string {
 	return print.P("AsDefault", print.BoolSilentTrue(bool(o)), print.SubOpt())
 }
+
+// Timeout limits how long the getter for this record is allowed to take each
+// time the configuration is compiled.  The context provided to a
+// [BufferContextGetter] or [ValueContextGetter] is canceled when the timeout
+// expires.  A value of 0 or less disables the timeout.
+//
+// # Default
+//
+// No timeout is applied beyond the context passed to [Config.CompileContext]().
+func Timeout(d time.Duration) BufferValueOption {
+	return timeoutOption(d)
+}
+
+type timeoutOption time.Duration
+
+func (t timeoutOption) bufferApply(opts *bufferOptions) error {
+	opts.timeout = time.Duration(t)
+	return nil
+}
+
+func (t timeoutOption) valueApply(opts *valueOptions) error {
+	opts.timeout = time.Duration(t)
+	return nil
+}
+
+func (t timeoutOption) String() string {
+	return print.P("Timeout", print.Literal(time.Duration(t).String()), print.SubOpt())
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// Decryptor decrypts the configuration values marked with the ((encrypted))
+// command.
+type Decryptor interface {
+	// Decrypt returns the plaintext of the ciphertext.  The errors returned
+	// must not include the plaintext.
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+}
+
+// The DecryptorFunc type is an adapter to allow the use of ordinary functions
+// as Decryptors. If f is a function with the appropriate signature,
+// DecryptorFunc(f) is a Decryptor that calls f.
+type DecryptorFunc func(context.Context, string) (string, error)
+
+// Decrypt calls f(ctx, ciphertext)
+func (f DecryptorFunc) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	return f(ctx, ciphertext)
+}
+
+var _ Decryptor = (*DecryptorFunc)(nil)
+
+// WithDecryptor sets the Decryptor used to decrypt the values marked with the
+// ((encrypted)) command as each record is compiled.  The decrypted values are
+// marked as secret, and are decrypted before any variable expansion is
+// performed.  The keys that are decrypted are recorded in the [Explanation],
+// but the values are not.
+//
+// Only one Decryptor is used.  The last one specified is used.  A nil
+// Decryptor removes the Decryptor.
+//
+// The optional label parameter allows you to provide the name of the Decryptor
+// so it is more clear which Decryptor is in use.
+//
+// # Default
+//
+// No Decryptor is set, and any values marked with the ((encrypted)) command
+// cause the compile to fail.
+func WithDecryptor(d Decryptor, label ...string) Option {
+	label = append(label, "")
+	return &decryptorOption{
+		label:     label[0],
+		decryptor: d,
+	}
+}
+
+type decryptorOption struct {
+	label     string
+	decryptor Decryptor
+}
+
+func (d decryptorOption) apply(opts *options) error {
+	opts.decryptor = d.decryptor
+	return nil
+}
+
+func (decryptorOption) ignoreDefaults() bool { return false }
+func (d decryptorOption) String() string {
+	labels := make([]string, 0, 1)
+	if len(d.label) > 0 {
+		labels = append(labels, d.label)
+	}
+	return print.P("WithDecryptor", print.Obj(d.decryptor, labels...))
+}
+
+// decrypt decrypts the values in the tree marked as encrypted.
+func (c *Config) decrypt(ctx context.Context, tree meta.Object) (meta.Object, error) {
+	return tree.Decrypt(func(path []string, obj meta.Object) (any, error) {
+		key := strings.Join(path, c.opts.keyDelimiter)
+
+		if c.opts.decryptor == nil {
+			return nil, fmt.Errorf("%w: no Decryptor for '%s' from %s",
+				ErrDecryptFailed, key, obj.OriginString())
+		}
+
+		ciphertext, ok := obj.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: '%s' from %s is not a string",
+				ErrDecryptFailed, key, obj.OriginString())
+		}
+
+		plaintext, err := c.opts.decryptor.Decrypt(ctx, ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s' from %s %w",
+				ErrDecryptFailed, key, obj.OriginString(), err)
+		}
+
+		c.explain.compileDecryption(fmt.Sprintf("'%s' from %s", key, obj.OriginString()))
+		return plaintext, nil
+	})
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecryptor(t *testing.T) {
+	errTest := errors.New("test error")
+
+	// A trivial decryptor that reverses the string.
+	reverse := DecryptorFunc(func(_ context.Context, s string) (string, error) {
+		if s == "" {
+			return "", errTest
+		}
+		r := []rune(s)
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+		return string(r), nil
+	})
+
+	tests := []struct {
+		description string
+		opts        []Option
+		expected    map[string]any
+		redacted    map[string]any
+		decryptions []string
+		expectedErr error
+	}{
+		{
+			description: "Encrypted values are decrypted and secret.",
+			opts: []Option{
+				WithDecryptor(reverse),
+				AddBuffer("1.json", []byte(`{"user":"bob", "password((encrypted))":"2retnuh"}`)),
+			},
+			expected: map[string]any{"user": "bob", "password": "hunter2"},
+			redacted: map[string]any{"user": "bob", "password": "REDACTED"},
+			decryptions: []string{
+				"'password' from 1.json",
+			},
+		}, {
+			description: "Values are decrypted before expansion.",
+			opts: []Option{
+				WithDecryptor(reverse),
+				Expand(ExpanderFunc(func(s string) (string, bool) {
+					return "expanded", s == "NAME"
+				})),
+				AddBuffer("1.json", []byte(`{"password((encrypted))":"}EMAN{$"}`)),
+			},
+			expected: map[string]any{"password": "expanded"},
+			redacted: map[string]any{"password": "REDACTED"},
+			decryptions: []string{
+				"'password' from 1.json",
+			},
+		}, {
+			description: "Values are decrypted as each record is merged.",
+			opts: []Option{
+				WithDecryptor(reverse),
+				AddBuffer("1.json", []byte(`{"password((encrypted))":""}`)),
+				AddBuffer("2.json", []byte(`{"password":"plain"}`)),
+			},
+			expectedErr: ErrDecryptFailed,
+		}, {
+			description: "No decryptor.",
+			opts: []Option{
+				AddBuffer("1.json", []byte(`{"password((encrypted))":"2retnuh"}`)),
+			},
+			expectedErr: ErrDecryptFailed,
+		}, {
+			description: "The decryptor fails.",
+			opts: []Option{
+				WithDecryptor(reverse),
+				AddBuffer("1.json", []byte(`{"password((encrypted))":""}`)),
+			},
+			expectedErr: errTest,
+		}, {
+			description: "The encrypted value isn't a string.",
+			opts: []Option{
+				WithDecryptor(reverse),
+				AddBuffer("1.json", []byte(`{"password((encrypted))":12}`)),
+			},
+			expectedErr: ErrDecryptFailed,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opts := []Option{
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				WithEncoder(&testEncoder{extensions: []string{"json"}}),
+				AutoCompile(),
+			}
+			opts = append(opts, tc.opts...)
+
+			cfg, err := New(opts...)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+			require.NoError(err)
+
+			var got map[string]any
+			require.NoError(cfg.Unmarshal(Root, &got))
+			assert.Equal(tc.expected, got)
+
+			b, err := cfg.Marshal(RedactSecrets())
+			require.NoError(err)
+			assert.NotContains(string(b), "hunter2")
+
+			explain := cfg.Explain()
+			// The line numbers from the test decoder depend on the map order.
+			require.Equal(len(tc.decryptions), len(explain.Decryptions))
+			for i, prefix := range tc.decryptions {
+				assert.True(strings.HasPrefix(explain.Decryptions[i], prefix))
+			}
+			assert.False(strings.Contains(explain.String(), "hunter2"))
+		})
+	}
+}
@@ -27,7 +27,20 @@
 //     during output of portions of the configuration tree.
 //   - Configuration fields may instruct the merge process of how the new field
 //     should merge with the existing field.  ('replace', 'keep', 'fail',
-//     'append', 'prepend', 'clear')
+//     'append', 'prepend', 'union', 'merge-by', 'delete', 'clear')
+//   - Configuration fields may be labeled as 'final' so records that follow
+//     can't change them.
+//   - Configuration fields may be labeled as 'encrypted' so they are decrypted
+//     by a Decryptor during the compile and marked as 'secret'.
+//   - Configuration fields may be labeled as 'deprecated', or renamed with
+//     KeyAlias, so their use is reported as a warning in the Explanation.
+//   - Struct fields may provide default values via the 'default' tag, so the
+//     defaults don't need to be repeated as configuration.
+//   - Struct fields may be validated via the 'validate' tag with ValidateTags.
+//   - Structs may set their own defaults, validate and finalize themselves
+//     by implementing Defaulter, SelfValidator and Finalizer.
+//   - Unmarshaling can report the used, ignored and unset keys with their
+//     origins via UnmarshalReport.
 //   - Configuration file groups include a reference to the specific io.fs, so
 //     configuration may come from anything that implements that interface.
 //   - Package defaults are set via goschtalt.DefaultOptions, but can be replaced
@@ -85,8 +98,18 @@
 //   - replace - replaces any existing values encountered by this merge
 //   - keep    - keeps the existing values encountered by this merge
 //   - fail    - causes the merge to return an error and stop processing
+//   - delete  - removes the existing key and everything below it; the value
+//     provided is ignored and missing parents of the key are not created
 //   - clear   - causes all of the existing configuration tree to be deleted
 //   - secret  - this special command marks the field as secret
+//   - final   - this special command marks the field as final; any later
+//     record that changes the field causes the compile to fail
+//   - deprecated - this special command marks the field as deprecated; any
+//     later record that uses the field adds a warning to the Explanation
+//
+// Values also support:
+//   - encrypted - this special command marks the value as encrypted; the value
+//     is decrypted by the Decryptor set with WithDecryptor and marked as secret
 //
 // Maps support the following instructions:
 //   - splice  - merge the leaf nodes if possible instead of replacing the map entirely
@@ -94,12 +117,21 @@
 // Arrays support the following instructions:
 //   - append  - append this array to the existing array
 //   - prepend - prepend this array to the existing array
+//   - union   - append only the elements of this array that are not already
+//     present in the existing array (compared by value)
+//   - merge-by <field> - splice the maps in this array with the maps in the
+//     existing array that have the same value for the named field, and append
+//     the rest
 //
 // Default merging behaviors:
 //   - maps   - splice when possible, replace if splicing isn't possible
 //   - arrays - append
 //   - values - replace
 //
+// The default merging behaviors can be changed by the application using the
+// DefaultArrayMerge, DefaultMapMerge and MergeStrategyAt options.  Commands
+// found in the keys always take precedence.
+//
 // An example showing using a secret:
 //
 //	foo:
@@ -109,8 +141,20 @@
 //
 // The order of the instructions doesn't matter, nor does extra spaces around
 // the instructions.  You may comma separate them, or you may just use a space.
-// But you can only have one or two instructions (one MUST be secret if there are
-// two.
+// But you can only have one merge instruction, along with the optional secret,
+// final, deprecated and encrypted instructions.  The field name following
+// 'merge-by' is part of that instruction.
+//
+// An example merging a list of servers by name:
+//
+//	servers ((merge-by name)):
+//	  - name: a
+//	    port: 8080
+//
+// An example of a value that can't be changed by the records that follow:
+//
+//	tls:
+//	  min_version ((final)): 1.3
 //
 // # A bit more on secrets.
 //
@@ -119,6 +163,25 @@
 // configuration file with minimal work.  It's also handy if you output your
 // configuration values into a log so you don't accidentally leak your secrets.
 //
+// Since it's easy to forget the secret command, the SecretKeys option marks the
+// values with keys matching patterns like "*.password" as secret, and struct
+// fields added with AddValue can be tagged as secret:
+//
+//	type Database struct {
+//		User     string
+//		Password string `goschtalt:"password,secret"`
+//	}
+//
+// Once a secret is unmarshaled into a struct, it is just a value again.  Use
+// the Secret type for the fields that hold secrets so they are redacted when
+// printed, marshaled or logged, and use the FailOnPlainSecrets option to make
+// sure secrets are only ever unmarshaled into a Secret.
+//
+//	type Database struct {
+//		User     string
+//		Password goschtalt.Secret[string]
+//	}
+//
 // # How do I write my own configuration decoder?
 //
 // Examples of decoders exist in the extensions/decoders directory.  Of interest
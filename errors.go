@@ -16,4 +16,8 @@ var (
 	ErrFileMissing   = errors.New("required file is missing")
 	ErrUnsupported   = errors.New("feature is unsupported")
 	ErrHint          = errors.New("a hint found an issue")
+	ErrPatchFailed   = errors.New("patch could not be applied")
+	ErrSecretExposed = errors.New("a secret would be exposed")
+	ErrDecryptFailed = errors.New("decryption failed")
+	ErrInvalidValue  = errors.New("value is invalid")
 )
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// historyStep is a record that was merged to build the configuration tree.
+// Only the records are kept; the state of the tree after each record is
+// rebuilt when it is needed.
+type historyStep struct {
+	record    string
+	isDefault bool
+	patch     *patch
+	incoming  meta.Object
+}
+
+// ExplanationStep is a single step in the history of how a specific key in the
+// configuration arrived at its value.  Secret values are redacted.
+type ExplanationStep struct {
+	// Record is the name of the record that was merged or "" for the final
+	// variable expansion step.
+	Record string
+
+	// Default is true if the record was marked as a 'default' record.
+	Default bool
+
+	// Command is the merge command that was applied ('replace', 'keep',
+	// 'append', 'prepend', 'union', 'merge-by', 'splice', 'fail', 'delete',
+	// 'clear'), 'patch' if a patch altered the value or 'expand' if variable
+	// expansion altered the value.
+	Command string
+
+	// Origins are the origins of the portion of the record that affected the
+	// key.  For example, the origins of the 'delete' command that removed the
+	// key or one of its parents.
+	Origins []meta.Origin
+
+	// Before is the value prior to this step.  If the key was not present the
+	// Object is empty.
+	Before meta.Object
+
+	// After is the value after this step.  If the key is not present the
+	// Object is empty.
+	After meta.Object
+}
+
+func (es ExplanationStep) String() string {
+	if es.Record == "" {
+		return es.Command
+	}
+
+	user := "user"
+	if es.Default {
+		user = "default"
+	}
+	return fmt.Sprintf("'%s' <%s> %s", es.Record, user, es.Command)
+}
+
+// ExplainKey returns the ordered history of every record that touched the key
+// along with the merge command applied and the value before and after the
+// record was merged.  If variable expansion changed the value, a final step is
+// included showing the change.
+//
+// To explain the entire configuration tree, use goschtalt.Root [Root] instead
+// of "" for more clarity.
+func (c *Config) ExplainKey(key string) ([]ExplanationStep, error) {
+	return c.Snapshot().ExplainKey(key)
+}
+
+// ExplainKey returns the ordered history of every record that touched the key
+// along with the merge command applied and the value before and after the
+// record was merged.  If variable expansion changed the value, a final step is
+// included showing the change.
+//
+// To explain the entire configuration tree, use goschtalt.Root [Root] instead
+// of "" for more clarity.
+//
+// The history is rebuilt from the records each time ExplainKey is called, so
+// it is best suited to debugging instead of hot paths.
+func (s *Snapshot) ExplainKey(key string) ([]ExplanationStep, error) {
+	if s.compiledAt.Equal(time.Time{}) {
+		return nil, ErrNotCompiled
+	}
+
+	var path []string
+	if len(key) > 0 {
+		path = strings.Split(key, s.opts.keyDelimiter)
+	}
+
+	strategy := s.opts.strategy()
+
+	var steps []ExplanationStep
+	var before meta.Object
+	merged := meta.Object{Map: make(map[string]meta.Object)}
+	for _, h := range s.history {
+		var err error
+		merged, err = s.replay(merged, h, strategy)
+		if err != nil {
+			return nil, err
+		}
+
+		after, _ := merged.Fetch(path, s.opts.keyDelimiter)
+		_, cmd, touched := h.incoming.LookupWithStrategy(path, strategy)
+		if h.patch != nil {
+			cmd = "patch"
+		}
+
+		if !touched && cmd != "clear" && sameValue(before, after) {
+			continue
+		}
+
+		steps = append(steps, ExplanationStep{
+			Record:  h.record,
+			Default: h.isDefault,
+			Command: cmd,
+			Origins: originsOf(h.incoming, path),
+			Before:  before.ToRedacted(),
+			After:   after.ToRedacted(),
+		})
+
+		// The next merge alters the maps of the tree in place.
+		before = after.Clone()
+	}
+
+	final, err := s.tree.Fetch(path, s.opts.keyDelimiter)
+	if err != nil && len(steps) == 0 {
+		return nil, err
+	}
+
+	if !sameValue(before, final) {
+		steps = append(steps, ExplanationStep{
+			Command: "expand",
+			Before:  before.ToRedacted(),
+			After:   final.ToRedacted(),
+		})
+	}
+
+	return steps, nil
+}
+
+// replay merges the record into the tree the same way it was merged when the
+// configuration was compiled.  Encrypted values are not decrypted again; the
+// ciphertext is kept in place of the plaintext and is marked as secret.
+func (s *Snapshot) replay(tree meta.Object, h historyStep, strategy meta.Strategy) (meta.Object, error) {
+	var err error
+	if h.patch != nil {
+		tree, err = h.patch.apply(tree, s.opts.keyDelimiter)
+	} else {
+		tree, err = tree.MergeWithStrategy(h.incoming, strategy, s.opts.keyDelimiter)
+	}
+	if err == nil {
+		tree, err = tree.Decrypt(func(_ []string, obj meta.Object) (any, error) {
+			return obj.Value, nil
+		})
+	}
+	if err != nil {
+		return meta.Object{}, err
+	}
+
+	return s.opts.markSecrets(tree), nil
+}
+
+// originsOf returns the origins of the deepest Object found along the path.
+func originsOf(tree meta.Object, path []string) []meta.Origin {
+	for i := len(path); i >= 0; i-- {
+		if obj, _, found := tree.Lookup(path[:i]); found {
+			return obj.Origins
+		}
+	}
+	return nil
+}
+
+// sameValue compares the values of two trees ignoring the origins.  Secret
+// values are compared as redacted since the rebuilt history holds the
+// ciphertext of encrypted values instead of the plaintext.
+func sameValue(a, b meta.Object) bool {
+	return reflect.DeepEqual(a.ToRedacted().ToRaw(), b.ToRedacted().ToRaw())
+}
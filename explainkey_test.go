@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainKey(t *testing.T) {
+	type step struct {
+		record  string
+		isDef   bool
+		command string
+		before  any
+		after   any
+		origins []meta.Origin
+	}
+
+	common := []Option{
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		AddValue("defaults", Root, map[string]any{
+			"server": map[string]any{
+				"port": 80,
+			},
+		}, AsDefault()),
+		AddBuffer("1.json", []byte(`{"server":{"port":8080, "host":"${host}"}, "list":["a"]}`)),
+		AddBuffer("2.json", []byte(`{"server":{"port((keep))":9000}}`)),
+		AddBuffer("3.json", []byte(`{"server":{"port":9090}, "list":["b"]}`)),
+		AddBuffer("4.json", []byte(`{"other":"x", "pw((secret))":"abc"}`)),
+		AddBuffer("5.json", []byte(`{"server((replace))":{"port":9090, "host":"${host}"}}`)),
+		Expand(ExpanderFunc(func(s string) (string, bool) {
+			if s == "host" {
+				return "example.com", true
+			}
+			return "", false
+		})),
+	}
+
+	tests := []struct {
+		description string
+		key         string
+		opts        []Option
+		expected    []step
+		expectedErr error
+	}{
+		{
+			description: "A value changed by several records.",
+			key:         "server.port",
+			expected: []step{
+				{record: "defaults", isDef: true, command: "replace", after: 80},
+				{record: "1.json", command: "replace", before: 80, after: json.Number("8080")},
+				{record: "2.json", command: "keep", before: json.Number("8080"), after: json.Number("8080")},
+				{record: "3.json", command: "replace", before: json.Number("8080"), after: json.Number("9090")},
+				{record: "5.json", command: "replace", before: json.Number("9090"), after: json.Number("9090")},
+			},
+		}, {
+			description: "A value that is expanded.",
+			key:         "server.host",
+			expected: []step{
+				{record: "1.json", command: "replace", after: "${host}"},
+				{record: "5.json", command: "replace", before: "${host}", after: "${host}"},
+				{command: "expand", before: "${host}", after: "example.com"},
+			},
+		}, {
+			description: "An array.",
+			key:         "list",
+			expected: []step{
+				{record: "1.json", command: "append", after: []any{"a"}},
+				{record: "3.json", command: "append", before: []any{"a"}, after: []any{"a", "b"}},
+			},
+		}, {
+			description: "A secret value.",
+			key:         "pw",
+			expected: []step{
+				{record: "4.json", command: "replace", after: "REDACTED"},
+			},
+		}, {
+			description: "An encrypted value.",
+			key:         "token",
+			opts: []Option{
+				WithDecryptor(DecryptorFunc(func(_ context.Context, s string) (string, error) {
+					return strings.ToUpper(s), nil
+				})),
+				AddBuffer("6.json", []byte(`{"token((encrypted))":"abc"}`)),
+			},
+			expected: []step{
+				{record: "6.json", command: "replace", after: "REDACTED"},
+			},
+		}, {
+			description: "A value that was cleared.",
+			key:         "other",
+			opts: []Option{
+				AddBuffer("6.json", []byte(`{"ignored((clear))":"ignored"}`)),
+			},
+			expected: []step{
+				{record: "4.json", command: "replace", after: "x"},
+				{record: "6.json", command: "clear", before: "x"},
+			},
+		}, {
+			description: "A value that was deleted.",
+			key:         "server.port",
+			opts: []Option{
+				DeleteKey("6", "server"),
+				AddBuffer("7.json", []byte(`{"server":{"port":1}}`)),
+			},
+			expected: []step{
+				{record: "defaults", isDef: true, command: "replace", after: 80},
+				{record: "1.json", command: "replace", before: 80, after: json.Number("8080")},
+				{record: "2.json", command: "keep", before: json.Number("8080"), after: json.Number("8080")},
+				{record: "3.json", command: "replace", before: json.Number("8080"), after: json.Number("9090")},
+				{record: "5.json", command: "replace", before: json.Number("9090"), after: json.Number("9090")},
+				{record: "6", command: "delete", before: json.Number("9090"), origins: []meta.Origin{{File: "6"}}},
+				{record: "7.json", command: "replace", after: json.Number("1")},
+			},
+		}, {
+			description: "A map spliced over two records.",
+			key:         "srv",
+			opts: []Option{
+				AddBuffer("6.json", []byte(`{"srv":{"name":"x", "port":1}}`)),
+				AddBuffer("7.json", []byte(`{"srv":{"name":"y"}}`)),
+			},
+			expected: []step{
+				{
+					record:  "6.json",
+					command: "splice",
+					after:   map[string]any{"name": "x", "port": json.Number("1")},
+				}, {
+					record:  "7.json",
+					command: "splice",
+					before:  map[string]any{"name": "x", "port": json.Number("1")},
+					after:   map[string]any{"name": "y", "port": json.Number("1")},
+				},
+			},
+		}, {
+			description: "A key that is not present.",
+			key:         "missing",
+			expectedErr: meta.ErrNotFound,
+		}, {
+			description: "The configuration is not compiled.",
+			key:         "server",
+			opts:        []Option{AutoCompile(false)},
+			expectedErr: ErrNotCompiled,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opts := append(append([]Option{}, common...), tc.opts...)
+			cfg, err := New(opts...)
+			require.NoError(err)
+			require.NotNil(cfg)
+
+			got, err := cfg.ExplainKey(tc.key)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				assert.Nil(got)
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(len(tc.expected), len(got), "%v", got)
+			for i, want := range tc.expected {
+				assert.Equal(want.record, got[i].Record)
+				assert.Equal(want.isDef, got[i].Default)
+				assert.Equal(want.command, got[i].Command)
+				assert.Equal(want.before, got[i].Before.ToRaw())
+				assert.Equal(want.after, got[i].After.ToRaw())
+				if want.origins != nil {
+					assert.Equal(want.origins, got[i].Origins)
+				}
+			}
+		})
+	}
+}
+
+func TestExplanationStep_String(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("'a.json' <user> replace",
+		ExplanationStep{Record: "a.json", Command: "replace"}.String())
+	assert.Equal("'b' <default> splice",
+		ExplanationStep{Record: "b", Default: true, Command: "splice"}.String())
+	assert.Equal("expand", ExplanationStep{Command: "expand"}.String())
+}
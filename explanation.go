@@ -6,9 +6,11 @@ package goschtalt
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/goschtalt/goschtalt/pkg/debug"
+	"github.com/goschtalt/goschtalt/pkg/meta"
 )
 
 // Explanation is the structure that represents what happened last time the
@@ -42,6 +44,15 @@ type Explanation struct {
 	// applied.
 	VariableExpansions []string
 
+	// Decryptions is the ordered list of the keys of the encrypted values that
+	// were decrypted, along with their origins.  The values are never included.
+	Decryptions []string
+
+	// Warnings is the ordered list of problems found during the last
+	// compilation that didn't cause the compilation to fail, like the use of
+	// deprecated keys.
+	Warnings []ExplanationWarning
+
 	// CompileErrors is the ordered list of compilation errors encountered during
 	// the last compilation.
 	CompileErrors []error
@@ -77,6 +88,24 @@ func (er ExplanationRecord) String() string {
 	return fmt.Sprintf("'%s' <%s> (%s)", er.Name, user, er.Duration)
 }
 
+// ExplanationWarning is the structure that represents a problem found during
+// the compilation that didn't cause the compilation to fail.
+type ExplanationWarning struct {
+	Record  string        // The name of the record with the problem.
+	Key     string        // The key with the problem.
+	Message string        // A description of the problem.
+	Origins []meta.Origin // The origins of the value with the problem.
+}
+
+func (ew ExplanationWarning) String() string {
+	origins := meta.Object{Origins: ew.Origins}.OriginString()
+	if origins == "" {
+		origins = "unknown"
+	}
+
+	return fmt.Sprintf("'%s' %s (%s)", ew.Record, ew.Message, origins)
+}
+
 func (e *Explanation) reset() {
 	e.Options = []string{}
 	e.FileExtensions = []string{}
@@ -96,6 +125,8 @@ func (e *Explanation) compileReset() {
 	e.CompileStartedAt = time.Time{}
 	e.Records = []ExplanationRecord{}
 	e.VariableExpansions = []string{}
+	e.Decryptions = []string{}
+	e.Warnings = []ExplanationWarning{}
 	e.CompileErrors = []error{}
 }
 
@@ -103,6 +134,8 @@ func (e *Explanation) compileStartedAt(t time.Time) {
 	e.CompileStartedAt = t
 	e.Records = []ExplanationRecord{}
 	e.VariableExpansions = []string{}
+	e.Decryptions = []string{}
+	e.Warnings = []ExplanationWarning{}
 	e.CompileErrors = []error{}
 }
 
@@ -124,12 +157,73 @@ func (e *Explanation) compileExpansions(details string) {
 	e.VariableExpansions = append(e.VariableExpansions, details)
 }
 
+func (e *Explanation) compileDecryption(details string) {
+	e.Decryptions = append(e.Decryptions, details)
+}
+
+func (e *Explanation) compileWarnings(w []ExplanationWarning) {
+	e.Warnings = append(e.Warnings, w...)
+}
+
 func (e *Explanation) recordError(err error) {
 	if err != nil {
 		e.CompileErrors = append(e.CompileErrors, err)
 	}
 }
 
+// clone makes a copy of the explanation that doesn't share any of the lists.
+func (e Explanation) clone() Explanation {
+	e.Options = append([]string{}, e.Options...)
+	e.FileExtensions = append([]string{}, e.FileExtensions...)
+	e.Records = append([]ExplanationRecord{}, e.Records...)
+	e.VariableExpansions = append([]string{}, e.VariableExpansions...)
+	e.Decryptions = append([]string{}, e.Decryptions...)
+	e.Warnings = append([]ExplanationWarning{}, e.Warnings...)
+	e.CompileErrors = append([]error{}, e.CompileErrors...)
+	e.Keyremapping = debug.Collect{}
+
+	return e
+}
+
+// keymapCollector is a goroutine safe KeymapReporter that collects the key
+// remapping for the explanation while snapshots are being used concurrently.
+type keymapCollector struct {
+	mutex sync.Mutex
+	remap debug.Collect
+}
+
+var _ KeymapReporter = (*keymapCollector)(nil)
+
+// Report stores the mapping information.
+func (k *keymapCollector) Report(from, to string) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	k.remap.Report(from, to)
+}
+
+func (k *keymapCollector) reset() {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	k.remap.Reset()
+}
+
+// collect returns a copy of the mappings collected so far.
+func (k *keymapCollector) collect() debug.Collect {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	rv := debug.Collect{
+		Mapping: make(map[string]string, len(k.remap.Mapping)),
+	}
+	for from, to := range k.remap.Mapping {
+		rv.Mapping[from] = to
+	}
+
+	return rv
+}
+
 func (e Explanation) String() string {
 	var b strings.Builder
 
@@ -192,6 +286,28 @@ func (e Explanation) String() string {
 		}
 	}
 
+	fmt.Fprintln(&b, "")
+	fmt.Fprintln(&b, "## Decryptions processed in order.")
+	fmt.Fprintln(&b, "")
+	if len(e.Decryptions) == 0 {
+		fmt.Fprintln(&b, "  <none>")
+	} else {
+		for i, decryption := range e.Decryptions {
+			fmt.Fprintf(&b, "  %d. %s\n", i+1, decryption)
+		}
+	}
+
+	fmt.Fprintln(&b, "")
+	fmt.Fprintln(&b, "## Warnings")
+	fmt.Fprintln(&b, "")
+	if len(e.Warnings) == 0 {
+		fmt.Fprintln(&b, "  <none>")
+	} else {
+		for _, warning := range e.Warnings {
+			fmt.Fprintf(&b, "  - %s\n", warning)
+		}
+	}
+
 	fmt.Fprintln(&b, "")
 	fmt.Fprintln(&b, "# Structure name remapping")
 	fmt.Fprintln(&b, "")
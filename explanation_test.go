@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/goschtalt/goschtalt/pkg/meta"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,6 +44,20 @@ func TestExplainerRecord(t *testing.T) {
 	}
 }
 
+func TestExplainerWarning(t *testing.T) {
+	assert := assert.New(t)
+
+	w := ExplanationWarning{
+		Record:  "a.json",
+		Message: "'old' is deprecated",
+		Origins: []meta.Origin{{File: "a.json", Line: 2}, {File: "b.json"}},
+	}
+	assert.Equal("'a.json' 'old' is deprecated (a.json:2, b.json)", w.String())
+
+	w.Origins = nil
+	assert.Equal("'a.json' 'old' is deprecated (unknown)", w.String())
+}
+
 func TestCompileRecord(t *testing.T) {
 	tests := []struct {
 		description string
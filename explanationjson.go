@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// explanationJSON is the stable schema used when an Explanation is rendered as
+// JSON or logged.  Fields are only ever added to this schema, never renamed or
+// removed.
+type explanationJSON struct {
+	Options            []string          `json:"options"`
+	FileExtensions     []string          `json:"file_extensions"`
+	Compile            compileJSON       `json:"compile"`
+	Records            []recordJSON      `json:"records"`
+	VariableExpansions []string          `json:"variable_expansions"`
+	Decryptions        []string          `json:"decryptions"`
+	KeyRemapping       map[string]string `json:"key_remapping"`
+	Warnings           []warningJSON     `json:"warnings"`
+}
+
+type compileJSON struct {
+	StartedAt  time.Time   `json:"started_at"`
+	FinishedAt time.Time   `json:"finished_at"`
+	DurationNS int64       `json:"duration_ns"`
+	Errors     []errorJSON `json:"errors"`
+}
+
+type recordJSON struct {
+	Name       string `json:"name"`
+	Default    bool   `json:"default"`
+	DurationNS int64  `json:"duration_ns"`
+}
+
+type warningJSON struct {
+	Record  string   `json:"record"`
+	Key     string   `json:"key"`
+	Message string   `json:"message"`
+	Origins []string `json:"origins"`
+}
+
+type errorJSON struct {
+	Message   string   `json:"message"`
+	Sentinels []string `json:"sentinels"`
+}
+
+// sentinels is the ordered list of well known errors that are reported when
+// they are wrapped by a compile error.
+var sentinels = []struct {
+	name string
+	err  error
+}{
+	{"goschtalt.ErrAdaptFailure", ErrAdaptFailure},
+	{"goschtalt.ErrDecoding", ErrDecoding},
+	{"goschtalt.ErrEncoding", ErrEncoding},
+	{"goschtalt.ErrNotApplicable", ErrNotApplicable},
+	{"goschtalt.ErrNotCompiled", ErrNotCompiled},
+	{"goschtalt.ErrCodecNotFound", ErrCodecNotFound},
+	{"goschtalt.ErrInvalidInput", ErrInvalidInput},
+	{"goschtalt.ErrFileMissing", ErrFileMissing},
+	{"goschtalt.ErrUnsupported", ErrUnsupported},
+	{"goschtalt.ErrHint", ErrHint},
+	{"goschtalt.ErrPatchFailed", ErrPatchFailed},
+	{"goschtalt.ErrSecretExposed", ErrSecretExposed},
+	{"goschtalt.ErrDecryptFailed", ErrDecryptFailed},
+	{"goschtalt.ErrInvalidValue", ErrInvalidValue},
+	{"meta.ErrConflict", meta.ErrConflict},
+	{"meta.ErrFinal", meta.ErrFinal},
+	{"meta.ErrInvalidCommand", meta.ErrInvalidCommand},
+	{"meta.ErrNotFound", meta.ErrNotFound},
+	{"meta.ErrArrayOutOfBounds", meta.ErrArrayOutOfBounds},
+	{"meta.ErrInvalidIndex", meta.ErrInvalidIndex},
+	{"meta.ErrRecursionTooDeep", meta.ErrRecursionTooDeep},
+	{"meta.ErrNonSerializable", meta.ErrNonSerializable},
+	{"context.Canceled", context.Canceled},
+	{"context.DeadlineExceeded", context.DeadlineExceeded},
+}
+
+func toErrorJSON(err error) errorJSON {
+	rv := errorJSON{
+		Message:   err.Error(),
+		Sentinels: []string{},
+	}
+
+	for _, s := range sentinels {
+		if errors.Is(err, s.err) {
+			rv.Sentinels = append(rv.Sentinels, s.name)
+		}
+	}
+
+	return rv
+}
+
+func (e Explanation) toJSON() explanationJSON {
+	rv := explanationJSON{
+		Options:            append([]string{}, e.Options...),
+		FileExtensions:     append([]string{}, e.FileExtensions...),
+		Records:            make([]recordJSON, 0, len(e.Records)),
+		VariableExpansions: append([]string{}, e.VariableExpansions...),
+		Decryptions:        append([]string{}, e.Decryptions...),
+		KeyRemapping:       make(map[string]string, len(e.Keyremapping.Mapping)),
+		Warnings:           make([]warningJSON, 0, len(e.Warnings)),
+		Compile: compileJSON{
+			StartedAt:  e.CompileStartedAt,
+			FinishedAt: e.CompileFinishedAt,
+			DurationNS: int64(e.CompileFinishedAt.Sub(e.CompileStartedAt)),
+			Errors:     make([]errorJSON, 0, len(e.CompileErrors)),
+		},
+	}
+
+	for _, r := range e.Records {
+		rv.Records = append(rv.Records, recordJSON{
+			Name:       r.Name,
+			Default:    r.Default,
+			DurationNS: int64(r.Duration),
+		})
+	}
+
+	for _, w := range e.Warnings {
+		origins := make([]string, 0, len(w.Origins))
+		for _, origin := range w.Origins {
+			origins = append(origins, origin.String())
+		}
+		rv.Warnings = append(rv.Warnings, warningJSON{
+			Record:  w.Record,
+			Key:     w.Key,
+			Message: w.Message,
+			Origins: origins,
+		})
+	}
+
+	for _, err := range e.CompileErrors {
+		if err != nil {
+			rv.Compile.Errors = append(rv.Compile.Errors, toErrorJSON(err))
+		}
+	}
+
+	for from, to := range e.Keyremapping.Mapping {
+		rv.KeyRemapping[from] = to
+	}
+
+	return rv
+}
+
+// MarshalJSON renders the explanation as JSON using a stable schema.  Durations
+// are in nanoseconds and compile errors are rendered as their message along
+// with the names of the well known errors they wrap.
+func (e Explanation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON())
+}
+
+// LogValue implements the slog.LogValuer interface so the explanation can be
+// logged directly.  The attributes match the JSON schema.
+func (e Explanation) LogValue() slog.Value {
+	j := e.toJSON()
+
+	return slog.GroupValue(
+		slog.Any("options", j.Options),
+		slog.Any("file_extensions", j.FileExtensions),
+		slog.Group("compile",
+			slog.Time("started_at", j.Compile.StartedAt),
+			slog.Time("finished_at", j.Compile.FinishedAt),
+			slog.Int64("duration_ns", j.Compile.DurationNS),
+			slog.Any("errors", j.Compile.Errors),
+		),
+		slog.Any("records", j.Records),
+		slog.Any("variable_expansions", j.VariableExpansions),
+		slog.Any("decryptions", j.Decryptions),
+		slog.Any("key_remapping", j.KeyRemapping),
+		slog.Any("warnings", j.Warnings),
+	)
+}
+
+var _ json.Marshaler = Explanation{}
+var _ slog.LogValuer = Explanation{}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/goschtalt/goschtalt/pkg/debug"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplanationJSON(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		description string
+		in          Explanation
+		expected    string
+	}{
+		{
+			description: "An empty explanation.",
+			expected: `{
+				"options": [],
+				"file_extensions": [],
+				"compile": {
+					"started_at": "0001-01-01T00:00:00Z",
+					"finished_at": "0001-01-01T00:00:00Z",
+					"duration_ns": 0,
+					"errors": []
+				},
+				"records": [],
+				"variable_expansions": [],
+				"decryptions": [],
+				"key_remapping": {},
+				"warnings": []
+			}`,
+		}, {
+			description: "A full explanation.",
+			in: Explanation{
+				Options:            []string{"AutoCompile()"},
+				FileExtensions:     []string{"json"},
+				CompileStartedAt:   start,
+				CompileFinishedAt:  start.Add(time.Second),
+				Records:            []ExplanationRecord{{Name: "a.json", Default: true, Duration: time.Millisecond}},
+				VariableExpansions: []string{"Expand()"},
+				Decryptions:        []string{"'password' from a.json:3"},
+				CompileErrors: []error{
+					fmt.Errorf("bad thing %w", errors.New("unknown")),
+					fmt.Errorf("processing file %w: %w", ErrDecoding, meta.ErrConflict),
+				},
+				Keyremapping: debug.Collect{Mapping: map[string]string{"Foo": "foo"}},
+				Warnings: []ExplanationWarning{
+					{
+						Record:  "a.json",
+						Key:     "old",
+						Message: "'old' is deprecated",
+						Origins: []meta.Origin{{File: "a.json", Line: 2, Col: 3}},
+					},
+				},
+			},
+			expected: `{
+				"options": ["AutoCompile()"],
+				"file_extensions": ["json"],
+				"compile": {
+					"started_at": "2024-01-02T03:04:05Z",
+					"finished_at": "2024-01-02T03:04:06Z",
+					"duration_ns": 1000000000,
+					"errors": [
+						{"message": "bad thing unknown", "sentinels": []},
+						{"message": "processing file decoding error: a conflict has been detected",
+						 "sentinels": ["goschtalt.ErrDecoding", "meta.ErrConflict"]}
+					]
+				},
+				"records": [{"name": "a.json", "default": true, "duration_ns": 1000000}],
+				"variable_expansions": ["Expand()"],
+				"decryptions": ["'password' from a.json:3"],
+				"key_remapping": {"Foo": "foo"},
+				"warnings": [{"record": "a.json", "key": "old", "message": "'old' is deprecated",
+							  "origins": ["a.json:2[3]"]}]
+			}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			got, err := json.Marshal(tc.in)
+			require.NoError(err)
+			assert.JSONEq(tc.expected, string(got))
+
+			// The slog output must match the JSON schema.
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+			logger.Info("", "explanation", tc.in)
+
+			var logged map[string]json.RawMessage
+			require.NoError(json.Unmarshal(buf.Bytes(), &logged))
+			assert.JSONEq(tc.expected, string(logged["explanation"]))
+		})
+	}
+}
@@ -211,6 +211,31 @@ func (g filegroup) isDir(path string) (dir bool, err error) {
 	return dir, err
 }
 
+// fingerprint writes the names and contents of the files described by the
+// filegroup to w so changes to the files can be detected.  Files that are
+// missing or can't be read are skipped since the compile handles them.
+func (g filegroup) fingerprint(w io.Writer) error {
+	files, err := g.enumerate()
+	if err != nil {
+		return normalizeFileError(err)
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(g.fs, file)
+		if err != nil {
+			if err = normalizeFileError(err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\x00%d\x00", file, len(data))
+		_, _ = w.Write(data)
+	}
+
+	return nil
+}
+
 // filegroupsToRecords converts a list of filegroups into a list of records.
 func filegroupsToRecords(delimiter string, filegroups []filegroup, decoders *codecRegistry[decoder.Decoder]) ([]record, error) {
 	rv := make([]record, 0, len(filegroups))
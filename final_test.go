@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinal(t *testing.T) {
+	tests := []struct {
+		description string
+		opts        []Option
+		expected    map[string]any
+		expectedErr error
+		origins     []string
+	}{
+		{
+			description: "A final value that isn't changed.",
+			opts: []Option{
+				AddBuffer("1.json", []byte(`{"tls":{"version((final))":"1.3"}}`)),
+				AddBuffer("2.json", []byte(`{"tls":{"version":"1.3", "ciphers":["a"]}}`)),
+			},
+			expected: map[string]any{
+				"tls": map[string]any{
+					"version": "1.3",
+					"ciphers": []any{"a"},
+				},
+			},
+		}, {
+			description: "A final buffer value that is changed.",
+			opts: []Option{
+				AddBuffer("1.json", []byte(`{"tls":{"version((final))":"1.3"}}`)),
+				AddBuffer("2.json", []byte(`{"tls":{"version":"1.0"}}`)),
+			},
+			expectedErr: meta.ErrFinal,
+			origins:     []string{"from 1.json", "changed by 2.json"},
+		}, {
+			description: "A final value that is changed by keep.",
+			opts: []Option{
+				AddBuffer("1.json", []byte(`{"tls":{"version((final))":"1.3"}}`)),
+				AddBuffer("2.json", []byte(`{"tls((keep))":{}}`)),
+				AddBuffer("3.json", []byte(`{"tls((replace))":{}}`)),
+			},
+			expectedErr: meta.ErrFinal,
+			origins:     []string{"1.json", "3.json"},
+		}, {
+			description: "A final value from AddValue.",
+			opts: []Option{
+				AddValue("1", Root, map[string]any{"Port": 80}, Final()),
+				AddValue("2", Root, map[string]any{"Host": "a"}),
+			},
+			expected: map[string]any{
+				"Port": 80,
+				"Host": "a",
+			},
+		}, {
+			description: "A final value from AddValue that is changed.",
+			opts: []Option{
+				AddValue("1", Root, map[string]any{"Port": 80}, Final()),
+				AddValue("2", Root, map[string]any{"Port": 90}),
+			},
+			expectedErr: meta.ErrFinal,
+			origins:     []string{"'Port' from 1 ", "changed by 2"},
+		}, {
+			description: "A final value from AddValue that is deleted.",
+			opts: []Option{
+				AddValue("1", "tls", map[string]any{"Version": "1.3"}, Final()),
+				DeleteKey("2", "tls"),
+			},
+			expectedErr: meta.ErrFinal,
+			origins:     []string{"'tls.Version' from 1 ", "changed by 2"},
+		}, {
+			description: "A final value that is changed by a patch.",
+			opts: []Option{
+				AddValue("1", Root, map[string]any{"Port": 80}, Final()),
+				AddPatch("2.patch", []byte(`[{"op":"replace", "path":"/Port", "value":90}]`)),
+			},
+			expectedErr: meta.ErrFinal,
+			origins:     []string{"'Port' from 1 ", "changed by 2.patch"},
+		}, {
+			description: "A patch that sets a final value to the same number.",
+			opts: []Option{
+				AddValue("1", Root, map[string]any{"Port": 80, "Rate": 1.5}, Final()),
+				AddPatch("2.patch", []byte(`{"Port":80, "Rate":1.5}`)),
+				AddPatch("3.patch", []byte(`[{"op":"replace", "path":"/Port", "value":80}]`)),
+			},
+			expected: map[string]any{
+				"Port": 80,
+				"Rate": 1.5,
+			},
+		}, {
+			description: "A final empty map can have keys added.",
+			opts: []Option{
+				AddValue("1", Root, map[string]any{"m": map[string]any{}}, Final()),
+				AddValue("2", "m.x", 1),
+			},
+			expected: map[string]any{
+				"m": map[string]any{"x": 1},
+			},
+		}, {
+			description: "A final value changed with a different key delimiter.",
+			opts: []Option{
+				SetKeyDelimiter("/"),
+				AddValue("1", "tls", map[string]any{"Version": "1.3"}, Final()),
+				AddValue("2", "tls/Version", "1.0"),
+			},
+			expectedErr: meta.ErrFinal,
+			origins:     []string{"'tls/Version' from 1 ", "changed by 2"},
+		}, {
+			description: "A final value changed by a patch with a different key delimiter.",
+			opts: []Option{
+				SetKeyDelimiter("/"),
+				AddValue("1", "tls", map[string]any{"Version": "1.3"}, Final()),
+				AddPatch("2.patch", []byte(`{"tls":{"Version":"1.0"}}`)),
+			},
+			expectedErr: meta.ErrFinal,
+			origins:     []string{"'tls/Version' from 1 ", "changed by 2.patch"},
+		}, {
+			description: "A final value that is removed by a merge patch.",
+			opts: []Option{
+				AddValue("1", Root, map[string]any{"Port": 80}, Final()),
+				AddPatch("2.patch", []byte(`{"Port":null}`)),
+			},
+			expectedErr: meta.ErrFinal,
+		}, {
+			description: "A patch that leaves a final value alone.",
+			opts: []Option{
+				AddValue("1", Root, map[string]any{"Port": 80}, Final()),
+				AddPatch("2.patch", []byte(`[{"op":"add", "path":"/Host", "value":"a"}]`)),
+				AddValue("3", Root, map[string]any{"Port": 90}),
+			},
+			expectedErr: meta.ErrFinal,
+			origins:     []string{"'Port' from 1 ", "changed by 3"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opts := []Option{
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				AutoCompile(),
+			}
+			opts = append(opts, tc.opts...)
+
+			cfg, err := New(opts...)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				for _, origin := range tc.origins {
+					assert.ErrorContains(err, origin)
+				}
+				return
+			}
+			require.NoError(err)
+
+			var got map[string]any
+			require.NoError(cfg.Unmarshal(Root, &got))
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
@@ -4,10 +4,14 @@
 package goschtalt
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"path"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/goschtalt/goschtalt/pkg/decoder"
@@ -34,23 +38,28 @@ var DefaultOptions = []Option{}
 // otherwise specified.
 const defaultTag = "goschtalt"
 
+// defaultValueTag is the go structure tag that provides the default value of a
+// field when it is unmarshaled.
+const defaultValueTag = "default"
+
 // Config is a configurable, prioritized, merging configuration registry.
 type Config struct {
-	mutex      sync.Mutex
-	records    []string
-	tree       meta.Object
-	compiledAt time.Time
-	hash       []byte
-	explain    Explanation
+	mutex    sync.Mutex
+	snapshot atomic.Pointer[Snapshot]
+	explain  Explanation
+	remaps   keymapCollector
+	status   Status
 
 	rawOpts []Option
 	opts    options
+
+	subscriptions []*subscription
+	pending       []func()
 }
 
 // New creates a new goschtalt configuration instance with any number of options.
 func New(opts ...Option) (*Config, error) {
 	c := Config{
-		tree: meta.Object{},
 		opts: options{
 			decoders: newRegistry[decoder.Decoder](),
 			encoders: newRegistry[encoder.Encoder](),
@@ -72,7 +81,7 @@ func New(opts ...Option) (*Config, error) {
 // See also: [AutoCompile], [Compile], [New]
 func (c *Config) With(opts ...Option) error {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	defer c.unlock()
 
 	cfg := options{
 		decoders: newRegistry[decoder.Decoder](),
@@ -80,6 +89,7 @@ func (c *Config) With(opts ...Option) error {
 	}
 
 	c.explain.reset()
+	c.remaps.reset()
 
 	raw := append(c.rawOpts, opts...)
 
@@ -93,8 +103,8 @@ func (c *Config) With(opts ...Option) error {
 
 	if !ignoreDefaultOpts(raw) {
 		local := []Option{
-			DefaultUnmarshalOptions(KeymapReport(&c.explain.Keyremapping)),
-			DefaultValueOptions(KeymapReport(&c.explain.Keyremapping)),
+			DefaultUnmarshalOptions(KeymapReport(&c.remaps)),
+			DefaultValueOptions(KeymapReport(&c.remaps)),
 		}
 
 		full = append(full, local...)
@@ -127,7 +137,7 @@ func (c *Config) With(opts ...Option) error {
 	c.explain.extsSupported(c.opts.decoders.extensions())
 
 	if !c.opts.disableAutoCompile {
-		return c.compile()
+		return c.compile(context.Background())
 	}
 
 	return nil
@@ -136,34 +146,73 @@ func (c *Config) With(opts ...Option) error {
 // Compile reads in all the files configured using the options provided,
 // and merges the configuration trees into a single map for later use.
 func (c *Config) Compile() error {
+	return c.CompileContext(context.Background())
+}
+
+// CompileContext is the same as [Config.Compile]() except the ctx is provided
+// to the [BufferContextGetter] and [ValueContextGetter] getters.  If the ctx is
+// canceled or times out, the compilation stops and an error naming the record
+// being processed is returned.
+func (c *Config) CompileContext(ctx context.Context) error {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	defer c.unlock()
 
-	return c.compile()
+	return c.compile(ctx)
 }
 
 // compile is the internal compile function that ensures the results are also
 // recorded.
-func (c *Config) compile() error {
+func (c *Config) compile(ctx context.Context) error {
+	prev := c.Snapshot()
 	start := time.Now()
+	c.opts.observers.OnCompileStart()
 	c.explain.compileStartedAt(start)
-	e := c.compileInternal(start)
+	snap, e := c.compileInternal(ctx, start)
 	c.explain.CompileFinishedAt = time.Now()
 	c.explain.recordError(e)
-	return e
+
+	var hash []byte
+	if snap != nil {
+		hash = snap.hash
+	}
+	c.opts.observers.OnCompileEnd(hash, e)
+
+	c.status.LastAttempt = start
+	c.status.LastError = e
+	if e != nil {
+		c.status.LastFailure = start
+		c.status.LastFailureError = e
+		if c.opts.failClosed {
+			c.snapshot.Store(nil)
+		}
+		return e
+	}
+	c.status.LastSuccess = start
+	c.status.LastSuccessHash = snap.hash
+
+	snap.explain = c.explain.clone()
+	c.snapshot.Store(snap)
+	c.queueChanges(prev.tree)
+	return nil
 }
 
 // compileInternal is the internal compile function that does most of the work.
-func (c *Config) compileInternal(start time.Time) error {
+func (c *Config) compileInternal(ctx context.Context, start time.Time) (*Snapshot, error) {
 	full, defaultCount, err := c.getOrderedConfigs()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	merged := meta.Object{Map: make(map[string]meta.Object)}
+	strategy := c.opts.strategy()
 	records := make([]string, 0, len(full))
+	history := make([]historyStep, 0, len(full))
 
 	for i, cfg := range full {
+		if err = ctx.Err(); err != nil {
+			return nil, fmt.Errorf("processing record '%s' %w", cfg.name, err)
+		}
+
 		// Build an incremental snapshot of the configuration at this step so
 		// user provided functions can use the cfg values to acquire more if
 		// needed.
@@ -171,29 +220,53 @@ func (c *Config) compileInternal(start time.Time) error {
 
 		incremental, _, err = expandTree(incremental, c.opts.exapansionMax, c.opts.expansions)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		unmarshalFunc := func(key string, result any, opts ...UnmarshalOption) error {
 			// Pass in the merged value from this context and stage of processing.
-			return c.unmarshal(key, result, incremental, opts...)
+			return unmarshal(&c.opts, key, result, incremental, opts...)
 		}
 
-		if err = cfg.fetch(c.opts.keyDelimiter, unmarshalFunc, c.opts.decoders, c.opts.valueOptions); err != nil {
-			return err
+		fetchStart := time.Now()
+		err = cfg.fetch(ctx, c.opts.keyDelimiter, unmarshalFunc, c.opts.decoders, c.opts.valueOptions)
+		c.opts.observers.OnRecordFetched(cfg.name, time.Since(fetchStart), err)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("processing record '%s' %w", cfg.name, err)
+			}
+			return nil, err
 		}
-		merged, err = merged.Merge(cfg.tree)
+		if cfg.patch != nil {
+			merged, err = cfg.patch.apply(merged, c.opts.keyDelimiter)
+		} else {
+			c.explain.compileWarnings(c.opts.deprecations(merged, &cfg))
+			merged, err = merged.MergeWithStrategy(cfg.tree, strategy, c.opts.keyDelimiter)
+		}
+		if err == nil {
+			merged, err = c.decrypt(ctx, merged)
+		}
+		merged = c.opts.markSecrets(merged)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		c.opts.observers.OnMerge(cfg.name)
 		records = append(records, cfg.name)
+		history = append(history, historyStep{
+			record:    cfg.name,
+			isDefault: i < defaultCount,
+			patch:     cfg.patch,
+			incoming:  cfg.tree,
+		})
 		c.explain.compileRecord(cfg.name, i < defaultCount, time.Now())
 	}
 
 	// Expand the final tree to ensure all values are expanded.
+	expandStart := time.Now()
 	merged, _, err = expandTree(merged, c.opts.exapansionMax, c.opts.expansions)
+	c.opts.observers.OnExpansion(time.Since(expandStart), err)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Record the expansions in effect.
@@ -203,14 +276,22 @@ func (c *Config) compileInternal(start time.Time) error {
 
 	hash, err := c.opts.hasher.Hash(merged)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	c.records = records
-	c.tree = merged
-	c.compiledAt = start
-	c.hash = hash
-	return nil
+	// The options are never altered in place, so a shallow copy is enough to
+	// keep the snapshot consistent.
+	opts := c.opts
+
+	return &Snapshot{
+		tree:       merged,
+		compiledAt: start,
+		hash:       hash,
+		records:    records,
+		history:    history,
+		remaps:     c.remaps.collect(),
+		opts:       &opts,
+	}, nil
 }
 
 // getOrderedConfigs is a helper function that combines the different groups of
@@ -274,19 +355,13 @@ func (c *Config) OrderList(list []string) []string {
 
 // CompiledAt returns when the configuration was compiled.
 func (c *Config) CompiledAt() time.Time {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	return c.compiledAt
+	return c.Snapshot().CompiledAt()
 }
 
 // Hash returns the hash of the configuration; even if the configuration is
 // empty.  SetHasher() needs to be set to get a useful (non-empty) value.
 func (c *Config) Hash() []byte {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	return c.hash
+	return c.Snapshot().Hash()
 }
 
 // Explain returns a human focused explanation of how the configuration was
@@ -296,7 +371,10 @@ func (c *Config) Explain() Explanation {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	return c.explain
+	rv := c.explain.clone()
+	rv.Keyremapping = c.remaps.collect()
+
+	return rv
 }
 
 // GetTree returns a copy of the compiled tree.  This is useful for debugging
@@ -305,8 +383,5 @@ func (c *Config) Explain() Explanation {
 // The value returned is a deep clone & has nothing to do with the original
 // that still resides inside the Config object.
 func (c *Config) GetTree() meta.Object {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	return c.tree.Clone()
+	return c.Snapshot().GetTree()
 }
@@ -378,7 +378,7 @@ func TestCompileNotWin(t *testing.T) {
 				}
 
 				// check the file order
-				assert.Equal(tc.files, cfg.records)
+				assert.Equal(tc.files, cfg.Snapshot().Records())
 
 				assert.NotEmpty(tell)
 
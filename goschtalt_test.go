@@ -4,6 +4,7 @@
 package goschtalt
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -294,6 +295,18 @@ func TestCompile(t *testing.T) {
 				Madd:  "cat",
 			},
 			files: []string{"1.json", "2.json", "3.json"},
+		}, {
+			description: "A delete below a missing parent doesn't add the parents.",
+			opts: []Option{
+				AddBuffer("1.json", []byte(`{"Hello": "World"}`)),
+				AddBuffer("2.json", []byte(`{"Blue": {"sky((delete))": null}}`)),
+				DeleteKey("3", "nope.child.leaf"),
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+			},
+			expect: map[string]any{
+				"Hello": "World",
+			},
+			files: []string{"1.json", "2.json", "3"},
 		}, {
 			description: "A case with an encoded buffer that is invalid",
 			skipCompile: true,
@@ -968,9 +981,9 @@ func TestCompile(t *testing.T) {
 
 				// check the file order
 				if tc.files == nil {
-					assert.Empty(cfg.records)
+					assert.Empty(cfg.Snapshot().Records())
 				} else {
-					assert.Equal(tc.files, cfg.records)
+					assert.Equal(tc.files, cfg.Snapshot().Records())
 				}
 
 				assert.NotEmpty(tell)
@@ -990,7 +1003,7 @@ func TestCompile(t *testing.T) {
 
 			if !tc.skipCompile {
 				// check the file order is correct
-				assert.Empty(cfg.records)
+				assert.Empty(cfg.Snapshot().Records())
 				assert.NotEmpty(tell)
 			}
 		})
@@ -1237,3 +1250,97 @@ func TestSetMaxExpansions(t *testing.T) {
 		})
 	}
 }
+
+func TestCompileContext(t *testing.T) {
+	blocking := ValueContextGetterFunc(
+		func(ctx context.Context, _ string, _ Unmarshaler) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	blockingBuf := BufferContextGetterFunc(
+		func(ctx context.Context, _ string, _ Unmarshaler) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		description string
+		ctx         context.Context
+		opts        []Option
+		expectedErr error
+		record      string
+	}{
+		{
+			description: "A successful compile.",
+			ctx:         context.Background(),
+			opts: []Option{
+				AddValueContextGetter("record", Root,
+					ValueContextGetterFunc(func(context.Context, string, Unmarshaler) (any, error) {
+						return map[string]string{"hello": "world"}, nil
+					}),
+					Timeout(time.Second),
+				),
+				AddBufferContextGetter("record.json",
+					BufferContextGetterFunc(func(context.Context, string, Unmarshaler) ([]byte, error) {
+						return []byte(`{"water":"blue"}`), nil
+					}),
+				),
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+			},
+		}, {
+			description: "A value record times out.",
+			ctx:         context.Background(),
+			opts: []Option{
+				AddValueContextGetter("slow", Root, blocking, Timeout(time.Millisecond)),
+			},
+			expectedErr: context.DeadlineExceeded,
+			record:      "slow",
+		}, {
+			description: "A buffer record times out.",
+			ctx:         context.Background(),
+			opts: []Option{
+				AddBufferContextGetter("slow.json", blockingBuf, Timeout(time.Millisecond)),
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+			},
+			expectedErr: context.DeadlineExceeded,
+			record:      "slow.json",
+		}, {
+			description: "The compile is canceled.",
+			ctx:         canceled,
+			opts: []Option{
+				AddValue("first", Root, map[string]string{"hello": "world"}),
+			},
+			expectedErr: context.Canceled,
+			record:      "first",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opts := append(tc.opts, AutoCompile(false))
+			cfg, err := New(opts...)
+			require.NoError(err)
+			require.NotNil(cfg)
+
+			err = cfg.CompileContext(tc.ctx)
+
+			if tc.expectedErr == nil {
+				assert.NoError(err)
+				assert.Empty(cfg.Explain().CompileErrors)
+				return
+			}
+
+			assert.ErrorIs(err, tc.expectedErr)
+			assert.Contains(err.Error(), "'"+tc.record+"'")
+
+			errs := cfg.Explain().CompileErrors
+			require.Len(errs, 1)
+			assert.ErrorIs(errs[0], tc.expectedErr)
+		})
+	}
+}
@@ -8,8 +8,37 @@ package mapstructure
 
 import (
 	"errors"
+	"reflect"
 )
 
 var (
 	ErrDecoding = errors.New("error decoding")
 )
+
+// FieldError describes a problem decoding a specific field.
+type FieldError struct {
+	// Name is the path of the field in the result, for example "Server.Port".
+	Name string
+
+	// Field is the path of the Go field in the result, for example
+	// "Inner.Hosts[1]".  Unlike the Name, the tag names are not used.
+	Field string
+
+	// Keys is the path of the map keys and slice indexes in the input data to
+	// the value that could not be decoded.
+	Keys []string
+
+	// Type is the type of the field being decoded into.
+	Type reflect.Type
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
@@ -272,6 +272,21 @@ type DecoderConfig struct {
 	// defaults to "mapstructure"
 	TagName string
 
+	// DefaultTagName is the name of a tag that provides the default value for
+	// a field if the input doesn't have a value for the field.  The default
+	// may also be provided by the "default=" option in the TagName tag, which
+	// takes precedence.  For example:
+	//
+	//	Port  int           `mapstructure:"port,default=8080"`
+	//	Delay time.Duration `default:"5s"`
+	//
+	// Defaults are decoded as weakly typed input and the fields with a default
+	// are considered set.  Missing structs are filled in if any of the fields
+	// within have a default.  Missing pointers to structs are left nil unless
+	// the pointer field has a default of its own; an empty default (`default:""`)
+	// fills in the struct from the defaults of the fields within.
+	DefaultTagName string
+
 	// IgnoreUntaggedFields ignores all struct fields without explicit
 	// TagName, comparable to `mapstructure:"-"` as default behavior.
 	IgnoreUntaggedFields bool
@@ -280,6 +295,11 @@ type DecoderConfig struct {
 	// field name or tag. Defaults to `strings.EqualFold`. This can be used
 	// to implement case-sensitive tag values, support snake casing, etc.
 	MatchName func(mapKey, fieldName string) bool
+
+	// MapName is the function used to build the map key of a struct field
+	// name or tag when the key isn't present in the input, such as when the
+	// default of the field is used.  Defaults to returning the name as is.
+	MapName func(fieldName string) string
 }
 
 // A Decoder takes a raw interface value and turns it into structured
@@ -290,6 +310,14 @@ type DecoderConfig struct {
 // up the most basic Decoder.
 type Decoder struct {
 	config *DecoderConfig
+
+	// keys is the path of the keys in the input data to the value being
+	// decoded.
+	keys []string
+
+	// fields is the path of the Go fields, map keys and slice indexes in the
+	// result to the value being decoded.
+	fields []string
 }
 
 // Metadata contains information about decoding a structure that
@@ -306,6 +334,23 @@ type Metadata struct {
 	// but weren't set in the decoding process since there was no matching value
 	// in the input
 	Unset []string
+
+	// KeyPaths, UnusedPaths and UnsetPaths describe where each of the Keys,
+	// Unused and Unset are in the input and the result, in the same order.
+	KeyPaths    []MetadataPath
+	UnusedPaths []MetadataPath
+	UnsetPaths  []MetadataPath
+}
+
+// MetadataPath describes where a value is in the input and the result.
+type MetadataPath struct {
+	// Keys is the path of the keys in the input data to the value.  For unset
+	// fields the last key is the name of the field.
+	Keys []string
+
+	// Field is the path of the Go fields, map keys and slice indexes in the
+	// result to the value.  It is empty for unused keys.
+	Field string
 }
 
 // Decode takes an input structure and uses reflection to translate it to
@@ -405,6 +450,18 @@ func NewDecoder(config *DecoderConfig) (*Decoder, error) {
 		if config.Metadata.Unset == nil {
 			config.Metadata.Unset = make([]string, 0)
 		}
+
+		if config.Metadata.KeyPaths == nil {
+			config.Metadata.KeyPaths = make([]MetadataPath, 0)
+		}
+
+		if config.Metadata.UnusedPaths == nil {
+			config.Metadata.UnusedPaths = make([]MetadataPath, 0)
+		}
+
+		if config.Metadata.UnsetPaths == nil {
+			config.Metadata.UnsetPaths = make([]MetadataPath, 0)
+		}
 	}
 
 	if config.TagName == "" {
@@ -415,6 +472,10 @@ func NewDecoder(config *DecoderConfig) (*Decoder, error) {
 		config.MatchName = strings.EqualFold
 	}
 
+	if config.MapName == nil {
+		config.MapName = func(s string) string { return s }
+	}
+
 	result := &Decoder{
 		config: config,
 	}
@@ -442,15 +503,19 @@ func (d *Decoder) decode(name string, input interface{}, outVal reflect.Value) e
 		}
 	}
 
+	if input == nil && !d.config.ZeroFields && outVal.Kind() != reflect.Ptr &&
+		d.hasDefaults(outVal.Type(), map[reflect.Type]bool{}) {
+		// Without any data, the struct is still filled in with the defaults.
+		input = map[string]interface{}{}
+		inputVal = reflect.ValueOf(input)
+	}
+
 	if input == nil {
 		// If the data is nil, then we don't set anything, unless ZeroFields is set
 		// to true.
 		if d.config.ZeroFields {
 			outVal.Set(reflect.Zero(outVal.Type()))
-
-			if d.config.Metadata != nil && name != "" {
-				d.config.Metadata.Keys = append(d.config.Metadata.Keys, name)
-			}
+			d.addMetaKey(name)
 		}
 		return nil
 	}
@@ -459,9 +524,7 @@ func (d *Decoder) decode(name string, input interface{}, outVal reflect.Value) e
 		// If the input value is invalid, then we just set the value
 		// to be the zero value.
 		outVal.Set(reflect.Zero(outVal.Type()))
-		if d.config.Metadata != nil && name != "" {
-			d.config.Metadata.Keys = append(d.config.Metadata.Keys, name)
-		}
+		d.addMetaKey(name)
 		return nil
 	}
 
@@ -470,7 +533,8 @@ func (d *Decoder) decode(name string, input interface{}, outVal reflect.Value) e
 		var err error
 		input, err = DecodeHookExec(d.config.DecodeHook, inputVal, outVal)
 		if err != nil {
-			return errors.Join(ErrDecoding, fmt.Errorf("'%s': %w", name, err))
+			return d.fieldError(name, outVal,
+				errors.Join(ErrDecoding, fmt.Errorf("'%s': %w", name, err)))
 		}
 	}
 
@@ -511,13 +575,158 @@ func (d *Decoder) decode(name string, input interface{}, outVal reflect.Value) e
 
 	// If we reached here, then we successfully decoded SOMETHING, so
 	// mark the key as used if we're tracking metainput.
-	if addMetaKey && d.config.Metadata != nil && name != "" {
-		d.config.Metadata.Keys = append(d.config.Metadata.Keys, name)
+	if addMetaKey {
+		d.addMetaKey(name)
+	}
+
+	// Errors from the fields below this one already describe the field.
+	var fe *FieldError
+	if err != nil && !errors.As(err, &fe) {
+		err = d.fieldError(name, outVal, err)
 	}
 
 	return err
 }
 
+// defaultTagOption is the option in the TagName tag that provides the default.
+const defaultTagOption = ",default="
+
+// fieldDefault returns the default value of the field if it has one.  Structs
+// without a default of their own are given an empty map if any of the fields
+// within have a default so the defaults are applied.  Pointers to structs are
+// only given an empty map if they have an empty default of their own.
+func (d *Decoder) fieldDefault(field reflect.StructField) (interface{}, bool) {
+	def, found := d.tagDefault(field)
+	if found {
+		if def == "" && isStruct(field.Type) {
+			return map[string]interface{}{}, true
+		}
+		return def, true
+	}
+
+	if d.hasDefaults(field.Type, map[reflect.Type]bool{}) {
+		return map[string]interface{}{}, true
+	}
+
+	return nil, false
+}
+
+// tagDefault returns the default value of the field from the tags if it has
+// one.
+func (d *Decoder) tagDefault(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get(d.config.TagName)
+	if i := strings.Index(tag, defaultTagOption); i >= 0 {
+		return tag[i+len(defaultTagOption):], true
+	}
+
+	if d.config.DefaultTagName != "" {
+		return field.Tag.Lookup(d.config.DefaultTagName)
+	}
+
+	return "", false
+}
+
+// isStruct returns true if the type is a struct or a pointer to a struct.
+func isStruct(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
+
+// hasDefaults returns true if the type is a struct with fields that have
+// defaults.  Pointers are not followed since they are left nil unless they
+// have a default of their own.
+func (d *Decoder) hasDefaults(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	if typ.Kind() != reflect.Struct || seen[typ] {
+		return false
+	}
+	seen[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if _, found := d.tagDefault(field); found {
+			return true
+		}
+		if d.hasDefaults(field.Type, seen) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeDefault decodes the default value into the field.  Default values from
+// the tags are always weakly typed.
+func (d *Decoder) decodeDefault(name, key string, field reflect.StructField, def interface{}, val reflect.Value) error {
+	fieldName := key
+	if name != "" {
+		fieldName = name + "." + key
+	}
+
+	if _, ok := def.(string); ok {
+		weak := d.config.WeaklyTypedInput
+		d.config.WeaklyTypedInput = true
+		defer func() {
+			d.config.WeaklyTypedInput = weak
+		}()
+	}
+
+	return d.decodeAt(d.config.MapName(key), "."+field.Name, fieldName, def, val)
+}
+
+// decodeAt decodes the input found at the key below the present value in the
+// input data into the field below the present value in the result.
+func (d *Decoder) decodeAt(key, field, name string, input interface{}, outVal reflect.Value) error {
+	d.keys = append(d.keys, key)
+	d.fields = append(d.fields, field)
+	defer func() {
+		d.keys = d.keys[:len(d.keys)-1]
+		d.fields = d.fields[:len(d.fields)-1]
+	}()
+
+	return d.decode(name, input, outVal)
+}
+
+// addMetaKey records the key of the value being decoded as used if tracking
+// metadata.
+func (d *Decoder) addMetaKey(name string) {
+	if d.config.Metadata == nil || name == "" {
+		return
+	}
+
+	d.config.Metadata.Keys = append(d.config.Metadata.Keys, name)
+	d.config.Metadata.KeyPaths = append(d.config.Metadata.KeyPaths, MetadataPath{
+		Keys:  append([]string{}, d.keys...),
+		Field: d.fieldPath(),
+	})
+}
+
+// fieldPath returns the path of the Go field being decoded.
+func (d *Decoder) fieldPath() string {
+	return strings.TrimPrefix(strings.Join(d.fields, ""), ".")
+}
+
+// fieldError wraps the error with the details about the field being decoded.
+func (d *Decoder) fieldError(name string, outVal reflect.Value, err error) error {
+	var typ reflect.Type
+	if outVal.IsValid() {
+		typ = outVal.Type()
+	}
+
+	return &FieldError{
+		Name:  name,
+		Field: d.fieldPath(),
+		Keys:  append([]string{}, d.keys...),
+		Type:  typ,
+		Err:   err,
+	}
+}
+
 // This decodes a basic type (bool, int, string, etc.) and sets the
 // value to "data" of that type.
 func (d *Decoder) decodeBasic(name string, data interface{}, val reflect.Value) error {
@@ -899,7 +1108,7 @@ func (d *Decoder) decodeMapFromSlice(name string, dataVal reflect.Value, val ref
 	}
 
 	for i := 0; i < dataVal.Len(); i++ {
-		err := d.decode(
+		err := d.decodeAt(strconv.Itoa(i), "",
 			name+"["+strconv.Itoa(i)+"]",
 			dataVal.Index(i).Interface(), val)
 		if err != nil {
@@ -937,7 +1146,7 @@ func (d *Decoder) decodeMapFromMap(name string, dataVal reflect.Value, val refle
 
 		// First decode the key into the proper type
 		currentKey := reflect.Indirect(reflect.New(valKeyType))
-		if err := d.decode(fieldName, k.Interface(), currentKey); err != nil {
+		if err := d.decodeAt(k.String(), "["+k.String()+"]", fieldName, k.Interface(), currentKey); err != nil {
 			errs = append(errs, err)
 			continue
 		}
@@ -945,7 +1154,7 @@ func (d *Decoder) decodeMapFromMap(name string, dataVal reflect.Value, val refle
 		// Next decode the data into the proper type
 		v := dataVal.MapIndex(k).Interface()
 		currentVal := reflect.Indirect(reflect.New(valElemType))
-		if err := d.decode(fieldName, v, currentVal); err != nil {
+		if err := d.decodeAt(k.String(), "["+k.String()+"]", fieldName, v, currentVal); err != nil {
 			errs = append(errs, err)
 			continue
 		}
@@ -1213,7 +1422,7 @@ func (d *Decoder) decodeSlice(name string, data interface{}, val reflect.Value)
 		currentField := valSlice.Index(i)
 
 		fieldName := name + "[" + strconv.Itoa(i) + "]"
-		if err := d.decode(fieldName, currentData, currentField); err != nil {
+		if err := d.decodeAt(strconv.Itoa(i), "["+strconv.Itoa(i)+"]", fieldName, currentData, currentField); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -1287,7 +1496,7 @@ func (d *Decoder) decodeArray(name string, data interface{}, val reflect.Value)
 		currentField := valArray.Index(i)
 
 		fieldName := name + "[" + strconv.Itoa(i) + "]"
-		if err := d.decode(fieldName, currentData, currentField); err != nil {
+		if err := d.decodeAt(strconv.Itoa(i), "["+strconv.Itoa(i)+"]", fieldName, currentData, currentField); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -1368,7 +1577,8 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 		dataValKeysUnused[dataValKey.Interface()] = struct{}{}
 	}
 
-	targetValKeysUnused := make(map[interface{}]struct{})
+	// The unset keys are mapped to the name of the Go field.
+	targetValKeysUnused := make(map[interface{}]string)
 	var errs []error
 
 	// This slice will keep track of all the structs we'll be decoding.
@@ -1423,11 +1633,11 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 
 			if squash {
 				if fieldVal.Kind() != reflect.Struct {
-					errs = append(errs,
+					errs = append(errs, d.fieldError(name, val, errors.Join(
 						ErrDecoding,
 						fmt.Errorf("%s: unsupported type for squash: %s",
 							fieldType.Name, fieldVal.Kind()),
-					)
+					)))
 				} else {
 					structs = append(structs, fieldVal)
 				}
@@ -1475,9 +1685,19 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 			}
 
 			if !rawMapVal.IsValid() {
-				// There was no matching key in the map for the value in
-				// the struct. Remember it for potential errors and metadata.
-				targetValKeysUnused[fieldName] = struct{}{}
+				def, found := d.fieldDefault(field)
+				if !found {
+					// There was no matching key in the map for the value in
+					// the struct. Remember it for potential errors and metadata.
+					targetValKeysUnused[fieldName] = field.Name
+					continue
+				}
+
+				if fieldValue.CanSet() {
+					if err := d.decodeDefault(name, fieldName, field, def, fieldValue); err != nil {
+						errs = append(errs, err)
+					}
+				}
 				continue
 			}
 		}
@@ -1502,7 +1722,7 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 			fieldName = name + "." + fieldName
 		}
 
-		if err := d.decode(fieldName, rawMapVal.Interface(), fieldValue); err != nil {
+		if err := d.decodeAt(fmt.Sprint(rawMapKey.Interface()), "."+field.Name, fieldName, rawMapVal.Interface(), fieldValue); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -1534,7 +1754,7 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 		sort.Strings(keys)
 
 		err := fmt.Errorf("'%s' has invalid keys: %s", name, strings.Join(keys, ", "))
-		errs = append(errs, ErrDecoding, err)
+		errs = append(errs, d.fieldError(name, val, errors.Join(ErrDecoding, err)))
 	}
 
 	if d.config.ErrorUnset && len(targetValKeysUnused) > 0 {
@@ -1545,7 +1765,7 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 		sort.Strings(keys)
 
 		err := fmt.Errorf("'%s' has unset fields: %s", name, strings.Join(keys, ", "))
-		errs = append(errs, ErrDecoding, err)
+		errs = append(errs, d.fieldError(name, val, errors.Join(ErrDecoding, err)))
 	}
 
 	if len(errs) > 0 {
@@ -1561,14 +1781,26 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 			}
 
 			d.config.Metadata.Unused = append(d.config.Metadata.Unused, key)
+			d.config.Metadata.UnusedPaths = append(d.config.Metadata.UnusedPaths, MetadataPath{
+				Keys: append(append([]string{}, d.keys...), rawKey.(string)),
+			})
 		}
-		for rawKey := range targetValKeysUnused {
+		for rawKey, fieldName := range targetValKeysUnused {
 			key := rawKey.(string)
 			if name != "" {
 				key = name + "." + key
 			}
 
+			field := fieldName
+			if path := d.fieldPath(); path != "" {
+				field = path + "." + fieldName
+			}
+
 			d.config.Metadata.Unset = append(d.config.Metadata.Unset, key)
+			d.config.Metadata.UnsetPaths = append(d.config.Metadata.UnsetPaths, MetadataPath{
+				Keys:  append(append([]string{}, d.keys...), rawKey.(string)),
+				Field: field,
+			})
 		}
 	}
 
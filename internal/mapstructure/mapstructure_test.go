@@ -8,6 +8,7 @@ package mapstructure
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"reflect"
 	"sort"
@@ -2312,6 +2313,231 @@ func TestInvalidType(t *testing.T) {
 	}
 }
 
+func TestDecodeDefaults(t *testing.T) {
+	t.Parallel()
+
+	type Inner struct {
+		Host string `mapstructure:"host,default=localhost"`
+		Port int    `default:"8080"`
+	}
+	type Outer struct {
+		Name    string `mapstructure:",default=a,b"`
+		Enabled bool   `mapstructure:"enabled,default=true"`
+		Rate    float64
+		Inner   Inner
+		Ptr     *Inner `default:""`
+		None    struct{ X int }
+	}
+
+	config := &DecoderConfig{
+		DefaultTagName: "default",
+		ErrorUnset:     true,
+	}
+
+	t.Run("missing values", func(t *testing.T) {
+		var result Outer
+		cfg := *config
+		cfg.Result = &result
+		decoder, err := NewDecoder(&cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = decoder.Decode(map[string]interface{}{
+			"rate": 1.5,
+			"none": map[string]interface{}{"x": 1},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		expected := Outer{
+			Name:    "a,b",
+			Enabled: true,
+			Rate:    1.5,
+			Inner:   Inner{Host: "localhost", Port: 8080},
+			Ptr:     &Inner{Host: "localhost", Port: 8080},
+			None:    struct{ X int }{X: 1},
+		}
+		if !reflect.DeepEqual(expected, result) {
+			t.Errorf("expected %#v, got %#v", expected, result)
+		}
+	})
+
+	t.Run("values present", func(t *testing.T) {
+		var result Outer
+		cfg := *config
+		cfg.Result = &result
+		decoder, err := NewDecoder(&cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = decoder.Decode(map[string]interface{}{
+			"name":    "name",
+			"enabled": false,
+			"rate":    1.5,
+			"inner":   map[string]interface{}{"host": "example.com"},
+			"none":    map[string]interface{}{"x": 1},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if result.Name != "name" || result.Enabled || result.Inner.Host != "example.com" || result.Inner.Port != 8080 {
+			t.Errorf("unexpected result: %#v", result)
+		}
+	})
+
+	t.Run("missing values without defaults", func(t *testing.T) {
+		var result Outer
+		cfg := *config
+		cfg.Result = &result
+		decoder, err := NewDecoder(&cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = decoder.Decode(map[string]interface{}{})
+		if err == nil || !strings.Contains(err.Error(), "has unset fields: None, Rate") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("no input", func(t *testing.T) {
+		var result Inner
+		if err := Decode(nil, &result); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if result.Host != "localhost" {
+			t.Errorf("unexpected result: %#v", result)
+		}
+	})
+
+	t.Run("pointer without a default", func(t *testing.T) {
+		var result struct {
+			Name string
+			Ptr  *Inner
+		}
+		cfg := *config
+		cfg.ErrorUnset = false
+		cfg.Result = &result
+		decoder, err := NewDecoder(&cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = decoder.Decode(map[string]interface{}{"name": "a", "ptr": nil})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if result.Name != "a" || result.Ptr != nil {
+			t.Errorf("unexpected result: %#v", result)
+		}
+	})
+
+	t.Run("invalid default", func(t *testing.T) {
+		var result struct {
+			Port int `mapstructure:"port,default=eighty"`
+		}
+		err := Decode(map[string]interface{}{}, &result)
+		if !errors.Is(err, ErrDecoding) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("invalid default with a mapped name", func(t *testing.T) {
+		var result struct {
+			Server struct {
+				ListenPort int `default:"eighty"`
+			}
+		}
+		decoder, err := NewDecoder(&DecoderConfig{
+			DefaultTagName: "default",
+			MapName:        strings.ToLower,
+			Result:         &result,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = decoder.Decode(map[string]interface{}{})
+		var fe *FieldError
+		if !errors.As(err, &fe) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := strings.Join(fe.Keys, "."); got != "server.listenport" {
+			t.Errorf("unexpected keys: %s", got)
+		}
+	})
+}
+
+func TestFieldError(t *testing.T) {
+	t.Parallel()
+
+	type Inner struct {
+		Port  int
+		Hosts []string
+	}
+	type Outer struct {
+		Name  string
+		Inner Inner `mapstructure:"server"`
+		Tags  map[string]int
+	}
+
+	input := map[string]interface{}{
+		"name": "ok",
+		"server": map[string]interface{}{
+			"port":  "eighty",
+			"hosts": []interface{}{"a", []int{1}},
+		},
+		"tags": map[string]interface{}{
+			"x": "y",
+		},
+	}
+
+	var result Outer
+	err := Decode(input, &result)
+	if err == nil {
+		t.Fatal("error should exist")
+	}
+
+	if !errors.Is(err, ErrDecoding) {
+		t.Errorf("expected ErrDecoding: %s", err)
+	}
+
+	var found []*FieldError
+	var walk func(error)
+	walk = func(err error) {
+		if fe, ok := err.(*FieldError); ok {
+			found = append(found, fe)
+			return
+		}
+		if list, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range list.Unwrap() {
+				walk(e)
+			}
+		}
+	}
+	walk(err)
+
+	got := map[string]string{}
+	for _, fe := range found {
+		got[strings.Join(fe.Keys, ".")] = fe.Name + " " + fe.Field + " " + fe.Type.String()
+	}
+
+	expected := map[string]string{
+		"server.port":    "server.Port Inner.Port int",
+		"server.hosts.1": "server.Hosts[1] Inner.Hosts[1] string",
+		"tags.x":         "Tags[x] Tags[x] int",
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
 func TestDecodeMetadata(t *testing.T) {
 	t.Parallel()
 
@@ -2442,6 +2668,78 @@ func TestMetadata_Embedded(t *testing.T) {
 	}
 }
 
+func TestMetadata_Paths(t *testing.T) {
+	t.Parallel()
+
+	type server struct {
+		Port  int `mapstructure:"port"`
+		Hosts []string
+		Name  string
+	}
+
+	type testResult struct {
+		Servers map[string]server
+	}
+
+	input := map[string]interface{}{
+		"Servers": map[string]interface{}{
+			"a": map[string]interface{}{
+				"port":  80,
+				"Hosts": []string{"x"},
+				"extra": true,
+			},
+		},
+	}
+
+	var md Metadata
+	var result testResult
+	config := &DecoderConfig{
+		Metadata: &md,
+		Result:   &result,
+	}
+
+	decoder, err := NewDecoder(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	err = decoder.Decode(input)
+	if err != nil {
+		t.Fatalf("err: %s", err.Error())
+	}
+
+	if len(md.KeyPaths) != len(md.Keys) {
+		t.Fatalf("bad key paths: %#v", md.KeyPaths)
+	}
+
+	found := false
+	for _, p := range md.KeyPaths {
+		if reflect.DeepEqual(p, MetadataPath{
+			Keys:  []string{"Servers", "a", "Hosts", "0"},
+			Field: "Servers[a].Hosts[0]",
+		}) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("bad key paths: %#v", md.KeyPaths)
+	}
+
+	expectedUnused := []MetadataPath{
+		{Keys: []string{"Servers", "a", "extra"}},
+	}
+	if !reflect.DeepEqual(md.UnusedPaths, expectedUnused) {
+		t.Fatalf("bad unused paths: %#v", md.UnusedPaths)
+	}
+
+	expectedUnset := []MetadataPath{
+		{Keys: []string{"Servers", "a", "Name"}, Field: "Servers[a].Name"},
+	}
+	if !reflect.DeepEqual(md.UnsetPaths, expectedUnset) {
+		t.Fatalf("bad unset paths: %#v", md.UnsetPaths)
+	}
+}
+
 func TestNonPtrValue(t *testing.T) {
 	t.Parallel()
 
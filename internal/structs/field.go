@@ -66,6 +66,11 @@ func (f *Field) Name() string {
 	return f.field.Name
 }
 
+// Type returns the type of the field.
+func (f *Field) Type() reflect.Type {
+	return f.field.Type
+}
+
 // Kind returns the fields kind, such as "string", "map", "bool", etc ..
 func (f *Field) Kind() reflect.Kind {
 	return f.value.Kind()
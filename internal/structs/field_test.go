@@ -250,6 +250,20 @@ func TestField_Kind(t *testing.T) {
 	}
 }
 
+func TestField_Type(t *testing.T) {
+	s := newStruct()
+
+	f := s.Field("A")
+	if f.Type() != reflect.TypeOf("") {
+		t.Errorf("Field A has wrong type: %s want: %s", f.Type(), reflect.TypeOf(""))
+	}
+
+	f = s.Field("B")
+	if f.Type() != reflect.TypeOf(0) {
+		t.Errorf("Field B has wrong type: %s want: %s", f.Type(), reflect.TypeOf(0))
+	}
+}
+
 func TestField_Tag(t *testing.T) {
 	s := newStruct()
 
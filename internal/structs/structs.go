@@ -33,6 +33,10 @@ var (
 	DefaultTagName = "structs" // struct's field default tag name
 )
 
+// secretCmd is the goschtalt command appended to the keys of the fields with
+// the "secret" option.
+const secretCmd = "((secret))"
+
 // Struct encapsulates a struct type to provide several high level functions
 // around the struct.
 type Struct struct {
@@ -95,6 +99,12 @@ func New(s interface{}) *Struct {
 //	// the field is skipped if empty.
 //	Field string `structs:",omitempty"`
 //
+// A tag value with the option of "secret" appends the "((secret))" command to
+// the key so the value is treated as a secret. Example:
+//
+//	// Field appears in map as key "password((secret))".
+//	Field string `structs:"password,secret"`
+//
 // Note that only exported fields of a struct can be accessed, non exported
 // fields will be neglected.
 func (s *Struct) Map() map[string]interface{} {
@@ -122,6 +132,9 @@ func (s *Struct) FillMap(out map[string]interface{}) { //nolint:gocognit
 		if tagName != "" {
 			name = tagName
 		}
+		if tagOpts.Has("secret") {
+			name += secretCmd
+		}
 
 		// if the value is a zero value and the field is marked as omitempty do
 		// not include
@@ -227,6 +227,38 @@ func TestMap_OmitEmpty(t *testing.T) {
 	}
 }
 
+func TestMap_Secret(t *testing.T) {
+	type B struct {
+		Key string
+	}
+	type A struct {
+		Name     string
+		Password string `structs:"password,secret"`
+		Token    string `structs:",secret"`
+		Nested   B      `structs:"nested,secret"`
+	}
+	a := A{Name: "name", Password: "pass", Token: "token", Nested: B{Key: "key"}}
+
+	m := Map(a)
+
+	if m["password((secret))"] != "pass" {
+		t.Errorf("Map should contain the password field with the secret command: %v", m)
+	}
+
+	if m["Token((secret))"] != "token" {
+		t.Errorf("Map should contain the Token field with the secret command: %v", m)
+	}
+
+	nested, ok := m["nested((secret))"].(map[string]interface{})
+	if !ok || nested["Key"] != "key" {
+		t.Errorf("Map should contain the nested field with the secret command: %v", m)
+	}
+
+	if m["Name"] != "name" {
+		t.Errorf("Map should contain the Name field unchanged: %v", m)
+	}
+}
+
 func TestMap_OmitNested(t *testing.T) {
 	type A struct {
 		Name  string
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// KeyAlias moves the values found at the deprecated oldKey to the newKey as
+// each record is compiled.  This allows configuration using the old key to
+// keep working after the key is renamed.  Any merge commands on the old key
+// are moved with the value.  If a record contains both keys, the value of the
+// new key is used and the value of the old key is dropped.
+//
+// Each record using the old key adds a warning with the origins of the value
+// to the [Explanation].
+//
+// Aliases are applied in the order they are specified.  Aliases are not
+// applied to records added by [AddPatch].
+//
+// See also: [AliasNote]
+func KeyAlias(oldKey, newKey string, opts ...KeyAliasOption) Option {
+	return &keyAliasOption{
+		text: print.P("KeyAlias", print.String(oldKey), print.String(newKey), print.LiteralStringers(opts)),
+		alias: keyAlias{
+			from: oldKey,
+			to:   newKey,
+		},
+		opts: opts,
+	}
+}
+
+type keyAlias struct {
+	from string
+	to   string
+	note string
+}
+
+type keyAliasOption struct {
+	text  string
+	alias keyAlias
+	opts  []KeyAliasOption
+}
+
+func (k keyAliasOption) apply(opts *options) error {
+	if k.alias.from == "" || k.alias.to == "" {
+		return fmt.Errorf("%w: KeyAlias keys can not be empty", ErrInvalidInput)
+	}
+	if k.alias.from == k.alias.to {
+		return fmt.Errorf("%w: KeyAlias keys must be different", ErrInvalidInput)
+	}
+
+	alias := k.alias
+	for _, opt := range k.opts {
+		if opt != nil {
+			opt.keyAliasApply(&alias)
+		}
+	}
+
+	opts.aliases = append(opts.aliases, alias)
+	return nil
+}
+
+func (keyAliasOption) ignoreDefaults() bool { return false }
+func (k keyAliasOption) String() string     { return k.text }
+
+// KeyAliasOption provides the means to configure a [KeyAlias].
+type KeyAliasOption interface {
+	fmt.Stringer
+
+	keyAliasApply(*keyAlias)
+}
+
+// AliasNote adds the note to the warnings about the use of the old key.  A
+// note is useful to explain when the old key will stop working.
+func AliasNote(note string) KeyAliasOption {
+	return aliasNoteOption(note)
+}
+
+type aliasNoteOption string
+
+func (a aliasNoteOption) keyAliasApply(alias *keyAlias) {
+	alias.note = string(a)
+}
+
+func (a aliasNoteOption) String() string {
+	return print.P("AliasNote", print.String(string(a)), print.SubOpt())
+}
+
+// deprecations applies the key aliases to the tree of the record and returns
+// the warnings about the deprecated keys used by the record.  The merged tree
+// is the tree the record will be merged with.
+func (o *options) deprecations(merged meta.Object, r *record) []ExplanationWarning {
+	var rv []ExplanationWarning
+	for _, alias := range o.aliases {
+		var origins []meta.Origin
+		var found bool
+
+		r.tree, origins, found = r.tree.Alias(
+			strings.Split(alias.from, o.keyDelimiter),
+			strings.Split(alias.to, o.keyDelimiter))
+		if !found {
+			continue
+		}
+
+		msg := fmt.Sprintf("'%s' is deprecated, use '%s' instead", alias.from, alias.to)
+		if alias.note != "" {
+			msg += "; " + alias.note
+		}
+
+		rv = append(rv, ExplanationWarning{
+			Record:  r.name,
+			Key:     alias.from,
+			Message: msg,
+			Origins: origins,
+		})
+	}
+
+	for _, d := range merged.Deprecations(r.tree) {
+		key := strings.Join(d.Key, o.keyDelimiter)
+		rv = append(rv, ExplanationWarning{
+			Record:  r.name,
+			Key:     key,
+			Message: fmt.Sprintf("'%s' is deprecated", key),
+			Origins: d.Origins,
+		})
+	}
+
+	return rv
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecatedKeys(t *testing.T) {
+	tests := []struct {
+		description string
+		opts        []Option
+		expected    map[string]any
+		warnings    []ExplanationWarning
+		expectedErr error
+	}{
+		{
+			description: "No deprecated keys are used.",
+			opts: []Option{
+				KeyAlias("server.addr", "server.host"),
+				AddBuffer("1.json", []byte(`{"server":{"host":"a"}}`)),
+			},
+			expected: map[string]any{
+				"server": map[string]any{"host": "a"},
+			},
+		}, {
+			description: "An aliased key is moved.",
+			opts: []Option{
+				KeyAlias("server.addr", "server.host"),
+				KeyAlias("timeout", "server.timeout", AliasNote("removed in v2")),
+				AddBuffer("1.json", []byte(`{"server":{"host":"a", "port":"80"}}`)),
+				AddBuffer("2.json", []byte(`{"server":{"addr":"b"}, "timeout((secret))":"1s"}`)),
+			},
+			expected: map[string]any{
+				"server": map[string]any{
+					"host":    "b",
+					"port":    "80",
+					"timeout": "1s",
+				},
+			},
+			warnings: []ExplanationWarning{
+				{
+					Record:  "2.json",
+					Key:     "server.addr",
+					Message: "'server.addr' is deprecated, use 'server.host' instead",
+					Origins: []meta.Origin{{File: "2.json", Col: 123}},
+				}, {
+					Record:  "2.json",
+					Key:     "timeout",
+					Message: "'timeout' is deprecated, use 'server.timeout' instead; removed in v2",
+					Origins: []meta.Origin{{File: "2.json", Col: 123}},
+				},
+			},
+		}, {
+			description: "The new key takes precedence in the same record.",
+			opts: []Option{
+				KeyAlias("addr", "host"),
+				AddBuffer("1.json", []byte(`{"addr":"a", "host":"b"}`)),
+			},
+			expected: map[string]any{
+				"host": "b",
+			},
+			warnings: []ExplanationWarning{
+				{
+					Record:  "1.json",
+					Key:     "addr",
+					Message: "'addr' is deprecated, use 'host' instead",
+					Origins: []meta.Origin{{File: "1.json", Col: 123}},
+				},
+			},
+		}, {
+			description: "A key marked as deprecated is used.",
+			opts: []Option{
+				AddValue("defaults", Root, map[string]any{
+					"server": map[string]any{"legacy((deprecated))": false},
+				}, AsDefault()),
+				AddBuffer("1.json", []byte(`{"server":{"legacy":true}}`)),
+				AddBuffer("2.json", []byte(`{"server":{"host":"a"}}`)),
+			},
+			expected: map[string]any{
+				"server": map[string]any{"legacy": true, "host": "a"},
+			},
+			warnings: []ExplanationWarning{
+				{
+					Record:  "1.json",
+					Key:     "server.legacy",
+					Message: "'server.legacy' is deprecated",
+					Origins: []meta.Origin{{File: "1.json", Col: 123}},
+				},
+			},
+		}, {
+			description: "A key marked as deprecated can't be a strategy.",
+			opts: []Option{
+				MergeStrategyAt("a", "deprecated"),
+			},
+			expectedErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opts := []Option{
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+			}
+			opts = append(opts, tc.opts...)
+
+			cfg, err := New(opts...)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+			require.NoError(err)
+
+			var got map[string]any
+			require.NoError(cfg.Unmarshal(Root, &got))
+			assert.Equal(tc.expected, got)
+
+			if tc.warnings == nil {
+				tc.warnings = []ExplanationWarning{}
+			}
+			// The line numbers from the test decoder depend on the map order.
+			warnings := cfg.Explain().Warnings
+			for _, w := range warnings {
+				for i := range w.Origins {
+					w.Origins[i].Line = 0
+				}
+			}
+			assert.Equal(tc.warnings, warnings)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// Defaulter is implemented by configuration structs that fill in their own
+// default values after they are unmarshaled.
+type Defaulter interface {
+	SetDefaults()
+}
+
+// SelfValidator is implemented by configuration structs that validate
+// themselves after they are unmarshaled.
+type SelfValidator interface {
+	Validate() error
+}
+
+// Finalizer is implemented by configuration structs that need to do work once
+// they are unmarshaled and valid, like deriving values from the configuration.
+type Finalizer interface {
+	Finalize() error
+}
+
+// lifecycle calls the Defaulter, SelfValidator and Finalizer methods of the
+// structs found in the result of unmarshaling.
+type lifecycle struct {
+	opts      *unmarshalOptions
+	obj       meta.Object
+	path      []string
+	delimiter string
+}
+
+// setDefaults calls SetDefaults on all the Defaulters.
+func (l *lifecycle) setDefaults(result any) {
+	_ = l.each(result, func(v any) error {
+		if d, ok := v.(Defaulter); ok {
+			d.SetDefaults()
+		}
+		return nil
+	})
+}
+
+// validate calls Validate on all the SelfValidators.
+func (l *lifecycle) validate(result any) error {
+	return l.each(result, func(v any) error {
+		if sv, ok := v.(SelfValidator); ok {
+			return sv.Validate()
+		}
+		return nil
+	})
+}
+
+// finalize calls Finalize on all the Finalizers.
+func (l *lifecycle) finalize(result any) error {
+	return l.each(result, func(v any) error {
+		if f, ok := v.(Finalizer); ok {
+			return f.Finalize()
+		}
+		return nil
+	})
+}
+
+// each calls the fn with each struct (or a pointer to it if possible) in the
+// result, the structs within a struct first.  The errors are returned as
+// UnmarshalErrors, joined together.
+func (l *lifecycle) each(result any, fn func(any) error) error {
+	var errs []error
+	l.walk(reflect.ValueOf(result), nil, "", true, func(val reflect.Value, keys []string, field string) {
+		if err := fn(val.Interface()); err != nil {
+			rv, _ := describeValue(l.obj, l.path, keys, l.delimiter)
+			rv.Field = field
+			rv.Expected = reflect.Indirect(val).Type().String()
+			rv.Err = err
+
+			// The value of a struct could contain secrets.
+			rv.Value = nil
+
+			errs = append(errs, rv)
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// walk calls the visit function for each struct below the value and then for
+// the value if it is a struct and self is true.  The keys are the path to the
+// value in the configuration and the field is the path of the Go field.
+func (l *lifecycle) walk(val reflect.Value, keys []string, field string, self bool, visit func(reflect.Value, []string, string)) {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		if _, ok := val.Interface().(revealer); ok {
+			return
+		}
+
+		typ := val.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			sf := typ.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+
+			name, squash := l.opts.fieldKey(sf.Tag.Get(l.opts.decoder.TagName), sf.Name)
+			if name == "-" {
+				continue
+			}
+
+			k := keys
+			if !squash {
+				k = append(keys[:len(keys):len(keys)], name)
+			}
+			f := sf.Name
+			if field != "" {
+				f = field + "." + f
+			}
+
+			// The methods of embedded structs are promoted to this struct, so
+			// only the structs within them are visited.
+			l.walk(val.Field(i), k, f, !sf.Anonymous, visit)
+		}
+
+		if self {
+			if val.CanAddr() {
+				val = val.Addr()
+			}
+			visit(val, keys, field)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if !mayHoldStructs(val.Type().Elem()) {
+			return
+		}
+		for i := 0; i < val.Len(); i++ {
+			idx := strconv.Itoa(i)
+			l.walk(val.Index(i), append(keys[:len(keys):len(keys)], idx), field+"["+idx+"]", true, visit)
+		}
+
+	case reflect.Map:
+		if !mayHoldStructs(val.Type().Elem()) {
+			return
+		}
+		mapKeys := val.MapKeys()
+		sort.Slice(mapKeys, func(i, j int) bool {
+			return fmt.Sprint(mapKeys[i].Interface()) < fmt.Sprint(mapKeys[j].Interface())
+		})
+		for _, k := range mapKeys {
+			key := fmt.Sprint(k.Interface())
+			keys := append(keys[:len(keys):len(keys)], key)
+			field := field + "[" + key + "]"
+
+			elem := val.MapIndex(k)
+			if elem.Kind() != reflect.Struct && elem.Kind() != reflect.Array {
+				l.walk(elem, keys, field, true, visit)
+				continue
+			}
+
+			// Map values can't be altered in place, so a copy is used and then
+			// stored back in the map.
+			cp := reflect.New(elem.Type()).Elem()
+			cp.Set(elem)
+			l.walk(cp, keys, field, true, visit)
+			val.SetMapIndex(k, cp)
+		}
+	}
+}
+
+// mayHoldStructs returns true if values of the type could be or contain structs.
+func mayHoldStructs(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// calls records the order the lifecycle methods are called in.
+var calls []string
+
+type lcTLS struct {
+	Cert string
+	Key  string
+}
+
+func (t *lcTLS) SetDefaults() {
+	calls = append(calls, "tls.SetDefaults")
+	if t.Key == "" {
+		t.Key = "key.pem"
+	}
+}
+
+func (t *lcTLS) Validate() error {
+	calls = append(calls, "tls.Validate")
+	if t.Cert == "missing.pem" {
+		return errors.New("the cert is missing")
+	}
+	return nil
+}
+
+func (t *lcTLS) Finalize() error {
+	calls = append(calls, "tls.Finalize")
+	return nil
+}
+
+type lcBase struct {
+	Name string
+}
+
+func (b *lcBase) Validate() error {
+	calls = append(calls, "base.Validate")
+	return nil
+}
+
+type lcServer struct {
+	lcBase `goschtalt:",squash"`
+	TLS    lcTLS
+	Port   int
+}
+
+func (s *lcServer) SetDefaults() {
+	calls = append(calls, "server.SetDefaults")
+	if s.Port == 0 {
+		s.Port = 443
+	}
+}
+
+func (s *lcServer) Finalize() error {
+	calls = append(calls, "server.Finalize")
+	if s.Port < 0 {
+		return fmt.Errorf("port %d can't be finalized", s.Port)
+	}
+	return nil
+}
+
+type lcConfig struct {
+	Servers map[string]lcServer
+	Main    *lcServer
+}
+
+func TestLifecycle(t *testing.T) {
+	tests := []struct {
+		description string
+		input       string
+		validator   Validator
+		expected    *lcConfig
+		calls       []string
+		paths       []string
+		fields      []string
+	}{
+		{
+			description: "All the methods are called bottom up.",
+			input:       `{"Main":{"Name":"main", "TLS":{"Cert":"cert.pem"}}}`,
+			expected: &lcConfig{
+				Main: &lcServer{
+					lcBase: lcBase{Name: "main"},
+					TLS:    lcTLS{Cert: "cert.pem", Key: "key.pem"},
+					Port:   443,
+				},
+			},
+			calls: []string{
+				"tls.SetDefaults", "server.SetDefaults",
+				"tls.Validate", "base.Validate",
+				"tls.Finalize", "server.Finalize",
+			},
+		}, {
+			description: "Structs in maps are updated.",
+			input:       `{"Servers":{"a":{"TLS":{"Cert":"a.pem"}}}}`,
+			expected: &lcConfig{
+				Servers: map[string]lcServer{
+					"a": {
+						TLS:  lcTLS{Cert: "a.pem", Key: "key.pem"},
+						Port: 443,
+					},
+				},
+			},
+			calls: []string{
+				"tls.SetDefaults", "server.SetDefaults",
+				"tls.Validate", "base.Validate",
+				"tls.Finalize", "server.Finalize",
+			},
+		}, {
+			description: "Validation errors include the path.",
+			input: `{"Servers":{"a":{"TLS":{"Cert":"missing.pem"}},
+					"b":{"TLS":{"Cert":"missing.pem"}}}}`,
+			calls: []string{
+				"tls.SetDefaults", "server.SetDefaults",
+				"tls.SetDefaults", "server.SetDefaults",
+				"tls.Validate", "base.Validate",
+				"tls.Validate", "base.Validate",
+			},
+			paths:  []string{"Servers.a.TLS", "Servers.b.TLS"},
+			fields: []string{"Servers[a].TLS", "Servers[b].TLS"},
+		}, {
+			description: "A validator error stops the finalize.",
+			input:       `{"Main":{"TLS":{"Cert":"cert.pem"}}}`,
+			validator: ValidatorFunc(func(any) error {
+				calls = append(calls, "validator")
+				return errors.New("invalid")
+			}),
+			calls: []string{
+				"tls.SetDefaults", "server.SetDefaults",
+				"tls.Validate", "base.Validate",
+				"validator",
+			},
+		}, {
+			description: "Finalize errors include the path.",
+			input:       `{"Main":{"Port":-1, "TLS":{"Cert":"cert.pem"}}}`,
+			calls: []string{
+				"tls.SetDefaults", "server.SetDefaults",
+				"tls.Validate", "base.Validate",
+				"tls.Finalize", "server.Finalize",
+			},
+			paths:  []string{"Main"},
+			fields: []string{"Main"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				AddBuffer("1.json", []byte(tc.input)),
+				AutoCompile(),
+			)
+			require.NoError(err)
+
+			calls = nil
+			var got lcConfig
+			err = cfg.Unmarshal(Root, &got, WithValidator(tc.validator))
+			assert.Equal(tc.calls, calls)
+
+			if tc.validator != nil {
+				assert.Error(err)
+				return
+			}
+
+			if tc.paths != nil {
+				require.Error(err)
+				joined, ok := err.(interface{ Unwrap() []error })
+				require.True(ok)
+
+				var paths, fields []string
+				for _, e := range joined.Unwrap() {
+					var ue *UnmarshalError
+					require.True(errors.As(e, &ue))
+					paths = append(paths, ue.Path)
+					fields = append(fields, ue.Field)
+					assert.Equal("1.json", ue.Origins[0].File)
+				}
+				assert.Equal(tc.paths, paths)
+				assert.Equal(tc.fields, fields)
+				return
+			}
+
+			require.NoError(err)
+			assert.Equal(tc.expected, &got)
+		})
+	}
+}
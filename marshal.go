@@ -5,9 +5,9 @@ package goschtalt
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
 )
 
 // Marshal renders the into the format specified ('json', 'yaml' or other extensions
@@ -19,19 +19,19 @@ import (
 //   - [GlobalOption]
 //   - [MarshalOption]
 func (c *Config) Marshal(opts ...MarshalOption) ([]byte, error) {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	if c.compiledAt.Equal(time.Time{}) {
-		return nil, ErrNotCompiled
-	}
+	return c.Snapshot().Marshal(opts...)
+}
 
+// marshal does the work of rendering the tree using the options provided.
+func marshal(o *options, tree meta.Object, opts ...MarshalOption) ([]byte, error) {
 	var cfg marshalOptions
-	exts := c.opts.encoders.extensions()
+	exts := o.encoders.extensions()
 	if len(exts) > 0 {
 		cfg.format = exts[0]
 	}
 
-	full := append(c.opts.marshalOptions, opts...)
+	// The options are shared by concurrent callers, so they are copied.
+	full := append(append([]MarshalOption{}, o.marshalOptions...), opts...)
 	for _, opt := range full {
 		if opt != nil {
 			if err := opt.marshalApply(&cfg); err != nil {
@@ -40,7 +40,6 @@ func (c *Config) Marshal(opts ...MarshalOption) ([]byte, error) {
 		}
 	}
 
-	tree := c.tree
 	if cfg.redactSecrets {
 		tree = tree.ToRedacted()
 	}
@@ -51,7 +50,7 @@ func (c *Config) Marshal(opts ...MarshalOption) ([]byte, error) {
 		return []byte{}, nil
 	}
 
-	enc, err := c.opts.encoders.find(cfg.format)
+	enc, err := o.encoders.find(cfg.format)
 	if err != nil {
 		return nil, err
 	}
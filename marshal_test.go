@@ -96,19 +96,22 @@ func TestMarshal(t *testing.T) {
 				now = time.Now()
 			}
 
-			c := Config{
-				tree:       tree,
-				compiledAt: now,
-				opts: options{
-					encoders:     newRegistry[encoder.Encoder](),
-					keyDelimiter: ".",
-				},
+			opts := options{
+				encoders:     newRegistry[encoder.Encoder](),
+				keyDelimiter: ".",
 			}
 
 			if !tc.noEncoders {
-				c.opts.encoders.register(&testEncoder{extensions: []string{"json"}})
+				opts.encoders.register(&testEncoder{extensions: []string{"json"}})
 			}
 
+			var c Config
+			c.snapshot.Store(&Snapshot{
+				tree:       tree,
+				compiledAt: now,
+				opts:       &opts,
+			})
+
 			got, err := c.Marshal(tc.opts...)
 
 			if tc.expectedErr == nil {
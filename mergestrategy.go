@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// MergeStrategy is the merge command to use when a key in a record doesn't
+// specify one.  The strategies are the same as the merge commands that can be
+// placed in the keys of a record.
+type MergeStrategy string
+
+const (
+	// MergeReplace replaces any existing values.  Valid for maps, arrays and
+	// values.
+	MergeReplace MergeStrategy = "replace"
+
+	// MergeKeep keeps the existing values.  Valid for maps, arrays and values.
+	MergeKeep MergeStrategy = "keep"
+
+	// MergeFail causes the merge to fail.  Valid for maps, arrays and values.
+	MergeFail MergeStrategy = "fail"
+
+	// MergeSplice merges the leaf nodes of maps.  Valid for maps.
+	MergeSplice MergeStrategy = "splice"
+
+	// MergeAppend appends the new array to the existing array.  Valid for
+	// arrays.
+	MergeAppend MergeStrategy = "append"
+
+	// MergePrepend prepends the new array to the existing array.  Valid for
+	// arrays.
+	MergePrepend MergeStrategy = "prepend"
+
+	// MergeUnion appends the elements of the new array that are not already
+	// present in the existing array.  Valid for arrays.
+	MergeUnion MergeStrategy = "union"
+)
+
+// MergeBy returns the strategy that merges the maps in arrays that have the
+// same value for the named field.  Valid for arrays.
+func MergeBy(field string) MergeStrategy {
+	return MergeStrategy("merge-by " + field)
+}
+
+// DefaultArrayMerge sets the strategy used to merge arrays when the key doesn't
+// specify a merge command.  The default is [MergeAppend].
+//
+// Explicit merge commands in the keys of a record always take precedence.
+//
+// See also: [MergeStrategyAt]
+func DefaultArrayMerge(s MergeStrategy) Option {
+	return &defaultMergeOption{
+		name:     "DefaultArrayMerge",
+		kind:     meta.Array,
+		strategy: s,
+	}
+}
+
+// DefaultMapMerge sets the strategy used to merge maps when the key doesn't
+// specify a merge command.  The default is [MergeSplice].  This includes the
+// root of the configuration tree unless a strategy is set for [Root] with
+// [MergeStrategyAt].
+//
+// Explicit merge commands in the keys of a record always take precedence.
+//
+// See also: [MergeStrategyAt]
+func DefaultMapMerge(s MergeStrategy) Option {
+	return &defaultMergeOption{
+		name:     "DefaultMapMerge",
+		kind:     meta.Map,
+		strategy: s,
+	}
+}
+
+type defaultMergeOption struct {
+	name     string
+	kind     int
+	strategy MergeStrategy
+}
+
+func (d defaultMergeOption) apply(opts *options) error {
+	if err := meta.ValidStrategy(d.kind, string(d.strategy)); err != nil {
+		return fmt.Errorf("%w: %s %w", ErrInvalidInput, d.name, err)
+	}
+
+	if d.kind == meta.Array {
+		opts.arrayMerge = d.strategy
+	} else {
+		opts.mapMerge = d.strategy
+	}
+	return nil
+}
+
+func (defaultMergeOption) ignoreDefaults() bool { return false }
+func (d defaultMergeOption) String() string {
+	return print.P(d.name, print.String(string(d.strategy)))
+}
+
+// MergeStrategyAt sets the strategy used to merge the specified key when the
+// key in the record doesn't specify a merge command.  The strategy at a key
+// takes precedence over the default strategies.  If the strategy isn't valid
+// for the kind of value found at the key, the compile fails.
+//
+// Explicit merge commands in the keys of a record always take precedence.
+//
+// To set the strategy for the root use `goschtalt.Root` ([Root]) instead of ""
+// for more clarity.
+//
+// See also: [DefaultArrayMerge], [DefaultMapMerge]
+func MergeStrategyAt(key string, s MergeStrategy) Option {
+	return &mergeStrategyAtOption{
+		key:      key,
+		strategy: s,
+	}
+}
+
+type mergeStrategyAtOption struct {
+	key      string
+	strategy MergeStrategy
+}
+
+func (m mergeStrategyAtOption) apply(opts *options) error {
+	var err error
+	for _, kind := range []int{meta.Array, meta.Map, meta.Value} {
+		if err = meta.ValidStrategy(kind, string(m.strategy)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: MergeStrategyAt %w", ErrInvalidInput, err)
+	}
+
+	if opts.mergeAt == nil {
+		opts.mergeAt = make(map[string]MergeStrategy)
+	}
+	opts.mergeAt[m.key] = m.strategy
+	return nil
+}
+
+func (mergeStrategyAtOption) ignoreDefaults() bool { return false }
+func (m mergeStrategyAtOption) String() string {
+	return print.P("MergeStrategyAt", print.String(m.key), print.String(string(m.strategy)))
+}
+
+// strategy returns the meta.Strategy based on the options, or nil if there
+// aren't any strategies.
+func (o *options) strategy() meta.Strategy {
+	if o.arrayMerge == "" && o.mapMerge == "" && len(o.mergeAt) == 0 {
+		return nil
+	}
+
+	delimiter := o.keyDelimiter
+	arrayMerge := o.arrayMerge
+	mapMerge := o.mapMerge
+	mergeAt := o.mergeAt
+
+	return func(path []string, kind int) string {
+		if s, found := mergeAt[strings.Join(path, delimiter)]; found {
+			return string(s)
+		}
+
+		switch kind {
+		case meta.Array:
+			return string(arrayMerge)
+		case meta.Map:
+			return string(mapMerge)
+		}
+		return ""
+	}
+}
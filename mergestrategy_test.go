@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeStrategies(t *testing.T) {
+	tests := []struct {
+		description string
+		opts        []Option
+		expected    map[string]any
+		command     string
+		expectedErr error
+	}{
+		{
+			description: "The default behavior.",
+			expected: map[string]any{
+				"list": []any{"a", "b"},
+				"tls": map[string]any{
+					"ciphers": []any{"x", "y"},
+					"version": json.Number("2"),
+					"extra":   "z",
+				},
+				"servers": []any{
+					map[string]any{"name": "a", "port": json.Number("1")},
+					map[string]any{"name": "a", "port": json.Number("2")},
+				},
+			},
+			command: "append",
+		}, {
+			description: "A default array merge.",
+			opts:        []Option{DefaultArrayMerge(MergeReplace)},
+			expected: map[string]any{
+				"list": []any{"a", "b"},
+				"tls": map[string]any{
+					"ciphers": []any{"y"},
+					"version": json.Number("2"),
+					"extra":   "z",
+				},
+				"servers": []any{
+					map[string]any{"name": "a", "port": json.Number("2")},
+				},
+			},
+			command: "replace",
+		}, {
+			description: "Strategies at keys.",
+			opts: []Option{
+				DefaultArrayMerge(MergePrepend),
+				DefaultMapMerge(MergeReplace),
+				MergeStrategyAt(Root, MergeSplice),
+				MergeStrategyAt("tls", MergeSplice),
+				MergeStrategyAt("tls.ciphers", MergeKeep),
+				MergeStrategyAt("servers", MergeBy("name")),
+			},
+			expected: map[string]any{
+				"list": []any{"a", "b"},
+				"tls": map[string]any{
+					"ciphers": []any{"x"},
+					"version": json.Number("2"),
+					"extra":   "z",
+				},
+				"servers": []any{
+					map[string]any{"name": "a", "port": json.Number("2")},
+				},
+			},
+			command: "keep",
+		}, {
+			description: "A default map merge includes the root.",
+			opts:        []Option{DefaultMapMerge(MergeReplace)},
+			expected: map[string]any{
+				"list": []any{"b"},
+				"tls": map[string]any{
+					"ciphers": []any{"y"},
+					"version": json.Number("2"),
+				},
+				"servers": []any{
+					map[string]any{"name": "a", "port": json.Number("2")},
+				},
+			},
+			command: "replace",
+		}, {
+			description: "A strategy at the parent of a deleted key.",
+			opts: []Option{
+				MergeStrategyAt("tls", MergeReplace),
+				DeleteKey("3", "tls.version"),
+			},
+			expected: map[string]any{
+				"list": []any{"a", "b"},
+				"tls": map[string]any{
+					"ciphers": []any{"y"},
+				},
+				"servers": []any{
+					map[string]any{"name": "a", "port": json.Number("1")},
+					map[string]any{"name": "a", "port": json.Number("2")},
+				},
+			},
+			command: "replace",
+		}, {
+			description: "A strategy that isn't valid for the value.",
+			opts:        []Option{MergeStrategyAt("tls", MergeUnion)},
+			expectedErr: meta.ErrInvalidCommand,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opts := []Option{
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				AddBuffer("1.json", []byte(`{
+					"list": ["a"],
+					"tls": {"ciphers": ["x"], "version": 1, "extra": "z"},
+					"servers": [{"name": "a", "port": 1}]
+				}`)),
+				AddBuffer("2.json", []byte(`{
+					"list((append))": ["b"],
+					"tls": {"ciphers": ["y"], "version": 2},
+					"servers": [{"name": "a", "port": 2}]
+				}`)),
+			}
+			opts = append(opts, tc.opts...)
+
+			cfg, err := New(opts...)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+			require.NoError(err)
+
+			var got map[string]any
+			require.NoError(cfg.Unmarshal(Root, &got))
+
+			assert.Equal(tc.expected, got)
+
+			steps, err := cfg.ExplainKey("tls.ciphers")
+			require.NoError(err)
+			require.Equal(2, len(steps))
+			assert.Equal(tc.command, steps[1].Command)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"time"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+)
+
+// Observer receives notifications as the configuration is compiled and used.
+// This is useful for feeding metrics and tracing systems.
+//
+// The compile related methods are called while the Config is locked, so they
+// must not call the Config methods that modify or explain the configuration.
+type Observer interface {
+	// OnCompileStart is called when a compile starts.
+	OnCompileStart()
+
+	// OnRecordFetched is called after each record has been fetched (read,
+	// decoded or obtained from a getter) along with how long it took and the
+	// error encountered if any.
+	OnRecordFetched(name string, d time.Duration, err error)
+
+	// OnMerge is called after each record has been merged into the tree.
+	OnMerge(name string)
+
+	// OnExpansion is called after the variable expansion of the final tree
+	// along with how long it took and the error encountered if any.
+	OnExpansion(d time.Duration, err error)
+
+	// OnCompileEnd is called when a compile finishes with the resulting hash
+	// or the error encountered.
+	OnCompileEnd(hash []byte, err error)
+
+	// OnUnmarshal is called each time the configuration is unmarshaled with
+	// the key requested and the error encountered if any.
+	OnUnmarshal(key string, err error)
+}
+
+// ObserverFuncs is an adapter to allow the use of ordinary functions as an
+// Observer.  Any of the functions may be nil, in which case that notification
+// is ignored.
+type ObserverFuncs struct {
+	CompileStart  func()
+	RecordFetched func(name string, d time.Duration, err error)
+	Merge         func(name string)
+	Expansion     func(d time.Duration, err error)
+	CompileEnd    func(hash []byte, err error)
+	Unmarshal     func(key string, err error)
+}
+
+var _ Observer = (*ObserverFuncs)(nil)
+
+// OnCompileStart calls CompileStart() if it is not nil.
+func (o ObserverFuncs) OnCompileStart() {
+	if o.CompileStart != nil {
+		o.CompileStart()
+	}
+}
+
+// OnRecordFetched calls RecordFetched(name, d, err) if it is not nil.
+func (o ObserverFuncs) OnRecordFetched(name string, d time.Duration, err error) {
+	if o.RecordFetched != nil {
+		o.RecordFetched(name, d, err)
+	}
+}
+
+// OnMerge calls Merge(name) if it is not nil.
+func (o ObserverFuncs) OnMerge(name string) {
+	if o.Merge != nil {
+		o.Merge(name)
+	}
+}
+
+// OnExpansion calls Expansion(d, err) if it is not nil.
+func (o ObserverFuncs) OnExpansion(d time.Duration, err error) {
+	if o.Expansion != nil {
+		o.Expansion(d, err)
+	}
+}
+
+// OnCompileEnd calls CompileEnd(hash, err) if it is not nil.
+func (o ObserverFuncs) OnCompileEnd(hash []byte, err error) {
+	if o.CompileEnd != nil {
+		o.CompileEnd(hash, err)
+	}
+}
+
+// OnUnmarshal calls Unmarshal(key, err) if it is not nil.
+func (o ObserverFuncs) OnUnmarshal(key string, err error) {
+	if o.Unmarshal != nil {
+		o.Unmarshal(key, err)
+	}
+}
+
+// WithObserver adds an Observer that is notified as the configuration is
+// compiled and unmarshaled.  Any number of observers may be added and they are
+// notified in the order they were added.  A nil Observer is ignored.
+func WithObserver(o Observer) Option {
+	return &observerOption{
+		observer: o,
+	}
+}
+
+type observerOption struct {
+	observer Observer
+}
+
+func (o observerOption) apply(opts *options) error {
+	if o.observer != nil {
+		opts.observers = append(opts.observers, o.observer)
+	}
+	return nil
+}
+
+func (observerOption) ignoreDefaults() bool { return false }
+func (o observerOption) String() string {
+	return print.P("WithObserver", print.Obj(o.observer))
+}
+
+// observerList notifies each of the observers in order.
+type observerList []Observer
+
+func (list observerList) OnCompileStart() {
+	for _, o := range list {
+		o.OnCompileStart()
+	}
+}
+
+func (list observerList) OnRecordFetched(name string, d time.Duration, err error) {
+	for _, o := range list {
+		o.OnRecordFetched(name, d, err)
+	}
+}
+
+func (list observerList) OnMerge(name string) {
+	for _, o := range list {
+		o.OnMerge(name)
+	}
+}
+
+func (list observerList) OnExpansion(d time.Duration, err error) {
+	for _, o := range list {
+		o.OnExpansion(d, err)
+	}
+}
+
+func (list observerList) OnCompileEnd(hash []byte, err error) {
+	for _, o := range list {
+		o.OnCompileEnd(hash, err)
+	}
+}
+
+func (list observerList) OnUnmarshal(key string, err error) {
+	for _, o := range list {
+		o.OnUnmarshal(key, err)
+	}
+}
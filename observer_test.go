@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testObserver struct {
+	events []string
+}
+
+func (o *testObserver) OnCompileStart() {
+	o.events = append(o.events, "start")
+}
+
+func (o *testObserver) OnRecordFetched(name string, _ time.Duration, err error) {
+	o.events = append(o.events, fmt.Sprintf("fetched %s %t", name, err == nil))
+}
+
+func (o *testObserver) OnMerge(name string) {
+	o.events = append(o.events, "merge "+name)
+}
+
+func (o *testObserver) OnExpansion(_ time.Duration, err error) {
+	o.events = append(o.events, fmt.Sprintf("expansion %t", err == nil))
+}
+
+func (o *testObserver) OnCompileEnd(hash []byte, err error) {
+	o.events = append(o.events, fmt.Sprintf("end %x %t", hash, err == nil))
+}
+
+func (o *testObserver) OnUnmarshal(key string, err error) {
+	o.events = append(o.events, fmt.Sprintf("unmarshal '%s' %t", key, err == nil))
+}
+
+func TestObserver(t *testing.T) {
+	unknownErr := errors.New("unknown")
+
+	tests := []struct {
+		description string
+		opts        []Option
+		expected    []string
+		expectedErr error
+	}{
+		{
+			description: "A successful compile and unmarshal.",
+			opts: []Option{
+				AddValue("a", Root, map[string]any{"Name": "a"}),
+				AddValue("b", Root, map[string]any{"Name": "b"}, AsDefault()),
+				SetHasher(HasherFunc(func(any) ([]byte, error) {
+					return []byte{0x01, 0x02}, nil
+				})),
+			},
+			expected: []string{
+				"start",
+				"fetched b true",
+				"merge b",
+				"fetched a true",
+				"merge a",
+				"expansion true",
+				"end 0102 true",
+				"unmarshal '' true",
+			},
+		}, {
+			description: "A record that fails to fetch.",
+			opts: []Option{
+				AddValue("a", Root, map[string]any{"Name": "a"}),
+				AddValueGetter("b", Root, &mockValueGetter{
+					f: func(string, Unmarshaler) (any, error) {
+						return nil, unknownErr
+					},
+				}),
+			},
+			expected: []string{
+				"start",
+				"fetched a true",
+				"merge a",
+				"fetched b false",
+				"end  false",
+			},
+			expectedErr: unknownErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var obs testObserver
+			opts := append([]Option{WithObserver(&obs), WithObserver(nil)}, tc.opts...)
+			cfg, err := New(opts...)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				assert.Equal(tc.expected, obs.events)
+				return
+			}
+
+			require.NoError(err)
+
+			var got struct {
+				Name string
+			}
+			err = cfg.Unmarshal(Root, &got)
+			require.NoError(err)
+			assert.Equal("a", got.Name)
+			assert.Equal(tc.expected, obs.events)
+		})
+	}
+}
+
+func TestObserverFuncs(t *testing.T) {
+	assert := assert.New(t)
+
+	// All nil functions must be safe.
+	var empty ObserverFuncs
+	empty.OnCompileStart()
+	empty.OnRecordFetched("a", time.Second, nil)
+	empty.OnMerge("a")
+	empty.OnExpansion(time.Second, nil)
+	empty.OnCompileEnd(nil, nil)
+	empty.OnUnmarshal("a", nil)
+
+	var called []string
+	full := ObserverFuncs{
+		CompileStart:  func() { called = append(called, "start") },
+		RecordFetched: func(string, time.Duration, error) { called = append(called, "fetched") },
+		Merge:         func(string) { called = append(called, "merge") },
+		Expansion:     func(time.Duration, error) { called = append(called, "expansion") },
+		CompileEnd:    func([]byte, error) { called = append(called, "end") },
+		Unmarshal:     func(string, error) { called = append(called, "unmarshal") },
+	}
+	full.OnCompileStart()
+	full.OnRecordFetched("a", time.Second, nil)
+	full.OnMerge("a")
+	full.OnExpansion(time.Second, nil)
+	full.OnCompileEnd(nil, nil)
+	full.OnUnmarshal("a", nil)
+
+	assert.Equal([]string{"start", "fetched", "merge", "expansion", "end", "unmarshal"}, called)
+}
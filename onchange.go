@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// subscription is a registered OnChange() handler.
+type subscription struct {
+	key string
+	fn  func(old, new meta.Object)
+}
+
+// OnChange registers fn to be called after a successful compile when the
+// portion of the configuration tree at the key is different than it was after
+// the previous compile.  The old and new values are deep clones, so they may be
+// kept or altered by fn.  If the key is not present in the tree, an empty
+// [meta.Object] is provided in its place.
+//
+// The handlers are called in the order they were registered, on the goroutine
+// that triggered the compile, after the Config is unlocked.  This means the
+// handlers are free to call [Config.Unmarshal]() and friends.
+//
+// To watch the entire configuration tree, use goschtalt.Root [Root] instead of
+// "" for more clarity.
+//
+// The returned function removes the handler.
+func (c *Config) OnChange(key string, fn func(old, new meta.Object)) (cancel func()) {
+	if fn == nil {
+		return func() {}
+	}
+
+	sub := &subscription{
+		key: key,
+		fn:  fn,
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.subscriptions = append(c.subscriptions, sub)
+
+	return func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		for i := range c.subscriptions {
+			if c.subscriptions[i] == sub {
+				c.subscriptions = append(c.subscriptions[:i], c.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// queueChanges compares the previous tree with the current tree for each
+// subscription & queues the handlers that need to be called.
+func (c *Config) queueChanges(prev meta.Object) {
+	for _, sub := range c.subscriptions {
+		was := c.subtree(prev, sub.key)
+		is := c.subtree(c.Snapshot().tree, sub.key)
+
+		if reflect.DeepEqual(was.ToRaw(), is.ToRaw()) {
+			continue
+		}
+
+		fn := sub.fn
+		was, is = was.Clone(), is.Clone()
+		c.pending = append(c.pending, func() {
+			fn(was, is)
+		})
+	}
+}
+
+// subtree returns the portion of the tree at the key or an empty object if the
+// key is not present.
+func (c *Config) subtree(tree meta.Object, key string) meta.Object {
+	if len(key) == 0 {
+		return tree
+	}
+
+	obj, err := tree.Fetch(strings.Split(key, c.opts.keyDelimiter), c.opts.keyDelimiter)
+	if err != nil {
+		return meta.Object{}
+	}
+
+	return obj
+}
+
+// unlock releases the mutex and then calls any queued change handlers.
+func (c *Config) unlock() {
+	pending := c.pending
+	c.pending = nil
+	c.mutex.Unlock()
+
+	for _, fn := range pending {
+		fn()
+	}
+}
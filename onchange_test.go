@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnChange(t *testing.T) {
+	base := map[string]any{
+		"db": map[string]any{
+			"host": "alpha",
+		},
+		"log": map[string]any{
+			"level": "info",
+		},
+	}
+
+	tests := []struct {
+		description string
+		key         string
+		cancel      bool
+		nilFn       bool
+		next        []Option
+		expectCalls int
+		expectOld   any
+		expectNew   any
+	}{
+		{
+			description: "The key changed.",
+			key:         "db",
+			next:        []Option{AddValue("over", "db.host", "beta")},
+			expectCalls: 1,
+			expectOld:   map[string]any{"host": "alpha"},
+			expectNew:   map[string]any{"host": "beta"},
+		}, {
+			description: "A leaf changed.",
+			key:         "db.host",
+			next:        []Option{AddValue("over", "db.host", "beta")},
+			expectCalls: 1,
+			expectOld:   "alpha",
+			expectNew:   "beta",
+		}, {
+			description: "A different key changed.",
+			key:         "log",
+			next:        []Option{AddValue("over", "db.host", "beta")},
+		}, {
+			description: "The root changed.",
+			key:         Root,
+			next:        []Option{AddValue("over", "db.host", "beta")},
+			expectCalls: 1,
+			expectOld:   base,
+			expectNew: map[string]any{
+				"db": map[string]any{
+					"host": "beta",
+				},
+				"log": map[string]any{
+					"level": "info",
+				},
+			},
+		}, {
+			description: "The key was added.",
+			key:         "cache",
+			next:        []Option{AddValue("over", "cache.size", 10)},
+			expectCalls: 1,
+			expectNew:   map[string]any{"size": 10},
+		}, {
+			description: "Nothing changed.",
+			key:         "db",
+			next:        []Option{AddValue("over", "db.host", "alpha")},
+		}, {
+			description: "The compile fails.",
+			key:         "db",
+			next: []Option{
+				AddValue("over", "db.host", "beta"),
+				SetHasher(HasherFunc(func(any) ([]byte, error) {
+					return nil, errOpt
+				})),
+			},
+		}, {
+			description: "The handler was canceled.",
+			key:         "db",
+			cancel:      true,
+			next:        []Option{AddValue("over", "db.host", "beta")},
+		}, {
+			description: "A nil handler.",
+			key:         "db",
+			nilFn:       true,
+			next:        []Option{AddValue("over", "db.host", "beta")},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(AddValue("base", Root, base))
+			require.NoError(err)
+			require.NotNil(cfg)
+
+			var calls int
+			var gotOld, gotNew meta.Object
+			fn := func(old, new meta.Object) {
+				calls++
+				gotOld, gotNew = old, new
+
+				// The handler must be able to use the configuration.
+				_, err := Unmarshal[map[string]any](cfg, Root)
+				assert.NoError(err)
+			}
+			if tc.nilFn {
+				fn = nil
+			}
+
+			cancel := cfg.OnChange(tc.key, fn)
+			require.NotNil(cancel)
+			if tc.cancel {
+				cancel()
+			}
+
+			_ = cfg.With(tc.next...)
+
+			assert.Equal(tc.expectCalls, calls)
+			if tc.expectCalls > 0 {
+				assert.Equal(tc.expectOld, gotOld.ToRaw())
+				assert.Equal(tc.expectNew, gotNew.ToRaw())
+			}
+
+			// Compiling again without changes must not call the handler again.
+			_ = cfg.Compile()
+			assert.Equal(tc.expectCalls, calls)
+		})
+	}
+}
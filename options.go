@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/fs"
 	"path"
+	"regexp"
 	"strings"
 
 	"github.com/goschtalt/goschtalt/internal/casbab"
@@ -45,6 +46,7 @@ type Option interface {
 type options struct {
 	// Settings where there are one.
 	disableAutoCompile bool
+	failClosed         bool
 	keyDelimiter       string
 	sorter             RecordSorter
 	hasher             Hasher
@@ -72,6 +74,23 @@ type options struct {
 	// Hints are special options that check that the configuration makes sense;
 	// there can be many.
 	hints []func(*options) error
+
+	// Observers; there can be many.
+	observers observerList
+
+	// The merge strategies used when the keys don't specify a merge command.
+	arrayMerge MergeStrategy
+	mapMerge   MergeStrategy
+	mergeAt    map[string]MergeStrategy
+
+	// Key aliases; there can be many.
+	aliases []keyAlias
+
+	// The decryptor for the encrypted values.
+	decryptor Decryptor
+
+	// The patterns of the keys to mark as secret; there can be many.
+	secretKeys []*regexp.Regexp
 }
 
 // ---- Options follow ---------------------------------------------------------
@@ -409,6 +428,38 @@ func (a disableAutoCompileOption) String() string {
 	return print.P("AutoCompile", print.BoolSilentTrue(bool(a)))
 }
 
+// FailClosed instructs the Config to discard the previously compiled
+// configuration when a compile fails if enable is true or omitted.  Once
+// discarded, [Config.Unmarshal]() and friends return [ErrNotCompiled] until a
+// compile succeeds.  Passing an enable value of false keeps the last
+// successfully compiled configuration in use (fail open).
+//
+// The enable bool value is optional & assumed to be `true` if omitted.  The
+// first specified value is used if provided.  A value of `false` disables the
+// option.
+//
+// See also: [Config.Status]
+//
+// # Default
+//
+// The last successfully compiled configuration is kept (fail open).
+func FailClosed(enable ...bool) Option {
+	enable = append(enable, true)
+	return failClosedOption(enable[0])
+}
+
+type failClosedOption bool
+
+func (f failClosedOption) apply(opts *options) error {
+	opts.failClosed = bool(f)
+	return nil
+}
+
+func (failClosedOption) ignoreDefaults() bool { return false }
+func (f failClosedOption) String() string {
+	return print.P("FailClosed", print.BoolSilentTrue(bool(f)))
+}
+
 // ConfigIs provides a strict field/key mapper that converts the config
 // values from the specified nomenclature into the go structure name.
 //
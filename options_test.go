@@ -4,12 +4,14 @@
 package goschtalt
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"sort"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/goschtalt/goschtalt/internal/fspath"
 	"github.com/goschtalt/goschtalt/pkg/decoder"
@@ -261,6 +263,111 @@ func TestOptions(t *testing.T) {
 			goal: options{
 				disableAutoCompile: true,
 			},
+		}, {
+			description: "FailClosed()",
+			opt:         FailClosed(),
+			str:         "FailClosed()",
+			goal: options{
+				failClosed: true,
+			},
+		}, {
+			description: "FailClosed(false)",
+			opt:         FailClosed(false),
+			str:         "FailClosed( false )",
+		}, {
+			description: "WithObserver( ObserverFuncs )",
+			opt:         WithObserver(ObserverFuncs{}),
+			str:         "WithObserver( goschtalt.ObserverFuncs )",
+			goal: options{
+				observers: observerList{ObserverFuncs{}},
+			},
+		}, {
+			description: "WithObserver( nil )",
+			opt:         WithObserver(nil),
+			str:         "WithObserver( nil )",
+		}, {
+			description: "WithDecryptor( DecryptorFunc, label )",
+			opt:         WithDecryptor(DecryptorFunc(nil), "label"),
+			str:         "WithDecryptor( label: goschtalt.DecryptorFunc )",
+			check: func(cfg *options) bool {
+				return cfg.decryptor != nil
+			},
+		}, {
+			description: "WithDecryptor( nil )",
+			opt:         WithDecryptor(nil),
+			str:         "WithDecryptor( nil )",
+		}, {
+			description: "SecretKeys( *.password, *token* )",
+			opt:         SecretKeys("*.password", "*token*"),
+			str:         "SecretKeys( '*.password', '*token*' )",
+			check: func(cfg *options) bool {
+				return len(cfg.secretKeys) == 2
+			},
+		}, {
+			description: "SecretKeys( '' )",
+			opt:         SecretKeys(""),
+			str:         "SecretKeys( '' )",
+			expectErr:   ErrInvalidInput,
+		}, {
+			description: "DefaultArrayMerge( replace )",
+			opt:         DefaultArrayMerge(MergeReplace),
+			str:         "DefaultArrayMerge( 'replace' )",
+			goal: options{
+				arrayMerge: MergeReplace,
+			},
+		}, {
+			description: "DefaultArrayMerge( splice )",
+			opt:         DefaultArrayMerge(MergeSplice),
+			str:         "DefaultArrayMerge( 'splice' )",
+			expectErr:   ErrInvalidInput,
+		}, {
+			description: "DefaultMapMerge( keep )",
+			opt:         DefaultMapMerge(MergeKeep),
+			str:         "DefaultMapMerge( 'keep' )",
+			goal: options{
+				mapMerge: MergeKeep,
+			},
+		}, {
+			description: "DefaultMapMerge( union )",
+			opt:         DefaultMapMerge(MergeUnion),
+			str:         "DefaultMapMerge( 'union' )",
+			expectErr:   ErrInvalidInput,
+		}, {
+			description: "MergeStrategyAt( a.b, merge-by name )",
+			opt:         MergeStrategyAt("a.b", MergeBy("name")),
+			str:         "MergeStrategyAt( 'a.b', 'merge-by name' )",
+			goal: options{
+				mergeAt: map[string]MergeStrategy{"a.b": "merge-by name"},
+			},
+		}, {
+			description: "MergeStrategyAt( a.b, delete )",
+			opt:         MergeStrategyAt("a.b", "delete"),
+			str:         "MergeStrategyAt( 'a.b', 'delete' )",
+			expectErr:   ErrInvalidInput,
+		}, {
+			description: "KeyAlias( a.b, c )",
+			opt:         KeyAlias("a.b", "c"),
+			str:         "KeyAlias( 'a.b', 'c' )",
+			goal: options{
+				aliases: []keyAlias{{from: "a.b", to: "c"}},
+			},
+		}, {
+			description: "KeyAlias( a, b, AliasNote( removed in v2 ) )",
+			opt:         KeyAlias("a", "b", AliasNote("removed in v2")),
+			str:         "KeyAlias( 'a', 'b', AliasNote('removed in v2') )",
+			goal: options{
+				aliases: []keyAlias{{from: "a", to: "b", note: "removed in v2"}},
+			},
+		}, {
+			description: "KeyAlias( '', b )",
+			opt:         KeyAlias("", "b"),
+			str:         "KeyAlias( '', 'b' )",
+			expectErr:   ErrInvalidInput,
+		}, {
+			description: "KeyAlias( a, a )",
+			opt:         KeyAlias("a", "a"),
+			str:         "KeyAlias( 'a', 'a' )",
+			expectErr:   ErrInvalidInput,
 		}, {
 			description: "SetKeyDelimiter( . )",
 			opt:         SetKeyDelimiter("."),
@@ -472,6 +579,42 @@ func TestOptions(t *testing.T) {
 					},
 				},
 			},
+		}, {
+			description: "DefaultUnmarshalOptions( FailOnPlainSecrets() )",
+			opt:         DefaultUnmarshalOptions(FailOnPlainSecrets()),
+			str:         "DefaultUnmarshalOptions( FailOnPlainSecrets() )",
+			goal: options{
+				unmarshalOptions: []UnmarshalOption{
+					failOnPlainSecretsOption(true),
+				},
+			},
+		}, {
+			description: "DefaultUnmarshalOptions( ValidateTags(false) )",
+			opt:         DefaultUnmarshalOptions(ValidateTags(false)),
+			str:         "DefaultUnmarshalOptions( ValidateTags(false) )",
+			goal: options{
+				unmarshalOptions: []UnmarshalOption{
+					validateTagsOption(false),
+				},
+			},
+		}, {
+			description: "DefaultUnmarshalOptions( UnmarshalReport(md) )",
+			opt:         DefaultUnmarshalOptions(UnmarshalReport(&UnmarshalMetadata{})),
+			str:         "DefaultUnmarshalOptions( UnmarshalReport(*goschtalt.UnmarshalMetadata) )",
+			goal: options{
+				unmarshalOptions: []UnmarshalOption{
+					&unmarshalReportOption{md: &UnmarshalMetadata{}},
+				},
+			},
+		}, {
+			description: "DefaultUnmarshalOptions( UnmarshalReport(nil) )",
+			opt:         DefaultUnmarshalOptions(UnmarshalReport(nil)),
+			str:         "DefaultUnmarshalOptions( UnmarshalReport(nil) )",
+			goal: options{
+				unmarshalOptions: []UnmarshalOption{
+					&unmarshalReportOption{},
+				},
+			},
 		}, {
 			description: "DefaultUnmarshalOptions( Optional() ), DefaultUnmarshalOptions( Required() )",
 			opts: []Option{
@@ -581,6 +724,44 @@ func TestOptions(t *testing.T) {
 				}
 				return false
 			},
+		}, {
+			description: "AddBufferContextGetter( filename.ext, func )",
+			opt: AddBufferContextGetter("filename.ext",
+				BufferContextGetterFunc(func(context.Context, string, Unmarshaler) ([]byte, error) {
+					return nil, nil
+				}),
+				Timeout(time.Second),
+			),
+			str: "AddBufferContextGetter( 'filename.ext', goschtalt.BufferContextGetterFunc, Timeout(1s) )",
+			check: func(cfg *options) bool {
+				if len(cfg.values) == 1 {
+					if cfg.values[0].name == "filename.ext" {
+						if cfg.values[0].buf.getter != nil {
+							return true
+						}
+					}
+				}
+				return false
+			},
+		}, {
+			description: "AddValueContextGetter( record1, '', func )",
+			opt: AddValueContextGetter("record1", Root,
+				ValueContextGetterFunc(func(context.Context, string, Unmarshaler) (any, error) {
+					return nil, nil
+				}),
+				Timeout(time.Second),
+			),
+			str: "AddValueContextGetter( 'record1', '', goschtalt.ValueContextGetterFunc, Timeout(1s) )",
+			check: func(cfg *options) bool {
+				if len(cfg.values) == 1 {
+					if cfg.values[0].name == "record1" {
+						if cfg.values[0].val.getter != nil {
+							return true
+						}
+					}
+				}
+				return false
+			},
 		}, {
 			description: "AddValueGetter( record1, '', func )",
 			opt: AddValueGetter("record1", Root,
@@ -641,6 +822,39 @@ func TestOptions(t *testing.T) {
 				}
 				return false
 			},
+		}, {
+			description: "DeleteKey( record1, key )",
+			opt:         DeleteKey("record1", "key"),
+			str:         "DeleteKey( 'record1', 'key' )",
+			check: func(cfg *options) bool {
+				if len(cfg.values) == 1 {
+					if cfg.values[0].name == "record1" {
+						if cfg.values[0].val.del {
+							return true
+						}
+					}
+				}
+				return false
+			},
+		}, {
+			description: "AddPatch( record1, patch )",
+			opt:         AddPatch("record1", []byte(`{"a":null}`)),
+			str:         "AddPatch( 'record1', []byte )",
+			check: func(cfg *options) bool {
+				if len(cfg.values) == 1 {
+					if cfg.values[0].name == "record1" {
+						if cfg.values[0].patch != nil {
+							return true
+						}
+					}
+				}
+				return false
+			},
+		}, {
+			description: "DeleteKey( record1, '' )",
+			opt:         DeleteKey("record1", Root),
+			str:         "DeleteKey( 'record1', '' )",
+			expectErr:   ErrInvalidInput,
 		}, {
 			description: "AddValue( record1, key, nil, AsDefault(false) )",
 			opt:         AddValue("record1", "key", nil, AsDefault(false)),
@@ -0,0 +1,516 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+const (
+	patchAdd     = "add"
+	patchRemove  = "remove"
+	patchReplace = "replace"
+	patchMove    = "move"
+	patchCopy    = "copy"
+	patchTest    = "test"
+)
+
+// AddPatch adds a patch that is applied to the configuration tree produced by
+// the records sorted before it instead of being merged like other records.  The
+// recordName field is used for sorting this patch relative to other records.
+//
+// The format of the patch is determined by the patch itself:
+//   - A JSON object is a JSON Merge Patch (RFC 7386).  The values are merged
+//     into the tree and a null value removes the key.
+//   - A JSON array is a JSON Patch (RFC 6902).  The 'add', 'remove',
+//     'replace', 'move', 'copy' and 'test' operations are supported.  If any
+//     operation fails, the compilation fails.
+//
+// The keys and paths in the patch must match the keys in the configuration tree
+// exactly.  Merge commands (like 'secret') are not supported in patches.
+func AddPatch(recordName string, patch []byte) Option {
+	return &patchOption{
+		text:       print.P("AddPatch", print.String(recordName), print.Bytes(patch)),
+		recordName: recordName,
+		patch:      patch,
+	}
+}
+
+type patchOption struct {
+	text       string
+	recordName string
+	patch      []byte
+}
+
+func (p patchOption) apply(opts *options) error {
+	if len(p.recordName) == 0 {
+		return fmt.Errorf("%w: no valid record name provided", ErrInvalidInput)
+	}
+
+	pat, err := parsePatch(p.recordName, p.patch)
+	if err != nil {
+		return err
+	}
+
+	opts.values = append(opts.values, record{
+		name:  p.recordName,
+		patch: pat,
+	})
+	return nil
+}
+
+func (patchOption) ignoreDefaults() bool { return false }
+func (p patchOption) String() string     { return p.text }
+
+// patch is a parsed patch that is ready to be applied.
+type patch struct {
+	recordName string
+
+	// merge is the RFC 7386 merge patch, or nil if this is a RFC 6902 patch.
+	merge map[string]any
+
+	// ops are the RFC 6902 operations.
+	ops []patchOp
+}
+
+type patchOp struct {
+	op    string
+	path  []string
+	from  []string
+	value any
+}
+
+// parsePatch determines the kind of patch and validates it.
+func parsePatch(name string, b []byte) (*patch, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: patch '%s' is not valid JSON: %w", ErrInvalidInput, name, err)
+	}
+
+	switch doc := doc.(type) {
+	case map[string]any:
+		return &patch{
+			recordName: name,
+			merge:      doc,
+		}, nil
+	case []any:
+		p := patch{
+			recordName: name,
+			ops:        make([]patchOp, 0, len(doc)),
+		}
+		for i, item := range doc {
+			op, err := toPatchOp(item)
+			if err != nil {
+				return nil, fmt.Errorf("%w: patch '%s' operation %d %s", ErrInvalidInput, name, i, err.Error())
+			}
+			p.ops = append(p.ops, op)
+		}
+		return &p, nil
+	}
+
+	return nil, fmt.Errorf("%w: patch '%s' must be a JSON object or array", ErrInvalidInput, name)
+}
+
+// toPatchOp validates and converts a single RFC 6902 operation.
+func toPatchOp(item any) (patchOp, error) {
+	m, ok := item.(map[string]any)
+	if !ok {
+		return patchOp{}, errors.New("is not an object")
+	}
+
+	var rv patchOp
+	rv.op, _ = m["op"].(string)
+
+	var err error
+	rv.path, err = pointerField(m, "path")
+	if err != nil {
+		return patchOp{}, err
+	}
+
+	switch rv.op {
+	case patchMove, patchCopy:
+		rv.from, err = pointerField(m, "from")
+		if err != nil {
+			return patchOp{}, err
+		}
+	case patchAdd, patchReplace, patchTest:
+		var found bool
+		rv.value, found = m["value"]
+		if !found {
+			return patchOp{}, fmt.Errorf("'%s' requires a value", rv.op)
+		}
+	case patchRemove:
+	default:
+		return patchOp{}, fmt.Errorf("has an unsupported op '%s'", rv.op)
+	}
+
+	if (rv.op == patchRemove && len(rv.path) == 0) || (rv.op == patchMove && len(rv.from) == 0) {
+		return patchOp{}, fmt.Errorf("'%s' can not be applied to the root", rv.op)
+	}
+
+	return rv, nil
+}
+
+// pointerField gets and parses the JSON Pointer (RFC 6901) found in the field.
+func pointerField(m map[string]any, field string) ([]string, error) {
+	s, ok := m[field].(string)
+	if !ok {
+		return nil, fmt.Errorf("requires a '%s' string", field)
+	}
+
+	if s == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("'%s' must start with '/'", field)
+	}
+
+	path := strings.Split(s[1:], "/")
+	for i := range path {
+		path[i] = strings.ReplaceAll(path[i], "~1", "/")
+		path[i] = strings.ReplaceAll(path[i], "~0", "~")
+	}
+
+	return path, nil
+}
+
+// pointer converts the path back into a JSON Pointer for error messages.
+func pointer(path []string) string {
+	var b strings.Builder
+	for _, p := range path {
+		p = strings.ReplaceAll(p, "~", "~0")
+		p = strings.ReplaceAll(p, "/", "~1")
+		b.WriteString("/" + p)
+	}
+	return b.String()
+}
+
+// apply applies the patch to the tree.  The tree is not altered, a new tree is
+// returned.  The keyDelimiter is used to join the keys in the errors.
+func (p *patch) apply(tree meta.Object, keyDelimiter string) (meta.Object, error) {
+	by := meta.Origin{File: p.recordName}
+	if p.merge != nil {
+		return tree.EnforceFinal(p.mergePatch(tree, p.merge), keyDelimiter, by)
+	}
+
+	result := tree
+	for i, op := range p.ops {
+		var err error
+		result, err = p.applyOp(result, op)
+		if err != nil {
+			return meta.Object{}, fmt.Errorf("patch '%s' operation %d %w", p.recordName, i, err)
+		}
+	}
+
+	return tree.EnforceFinal(result, keyDelimiter, by)
+}
+
+func (p *patch) toObject(v any) meta.Object {
+	return meta.ObjectFromRawWithOrigin(fromJSONNumbers(v), []meta.Origin{{File: p.recordName}})
+}
+
+// fromJSONNumbers converts the json.Number values to int or float64 values so
+// the numbers in the tree are the same as the numbers from the other records.
+func fromJSONNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil && int64(int(i)) == i {
+			return int(i)
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[k] = fromJSONNumbers(val)
+		}
+		return m
+	case []any:
+		a := make([]any, len(v))
+		for i, val := range v {
+			a[i] = fromJSONNumbers(val)
+		}
+		return a
+	}
+
+	return v
+}
+
+// mergePatch implements the RFC 7386 algorithm.
+func (p *patch) mergePatch(target meta.Object, in any) meta.Object {
+	m, ok := in.(map[string]any)
+	if !ok {
+		return p.toObject(in)
+	}
+
+	rv := p.toObject(map[string]any{})
+	if isMapObject(target) {
+		rv = target
+		rv.Map = make(map[string]meta.Object, len(target.Map))
+		for k, v := range target.Map {
+			rv.Map[k] = v
+		}
+	}
+
+	for k, v := range m {
+		if v == nil {
+			delete(rv.Map, k)
+			continue
+		}
+		rv.Map[k] = p.mergePatch(rv.Map[k], v)
+	}
+
+	return rv
+}
+
+// applyOp applies a single RFC 6902 operation.
+func (p *patch) applyOp(tree meta.Object, op patchOp) (meta.Object, error) {
+	switch op.op {
+	case patchAdd:
+		return addAt(tree, op.path, p.toObject(op.value))
+	case patchRemove:
+		return removeAt(tree, op.path)
+	case patchReplace:
+		if _, err := getAt(tree, op.path); err != nil {
+			return meta.Object{}, err
+		}
+		return replaceAt(tree, op.path, p.toObject(op.value))
+	case patchMove:
+		if len(op.path) > len(op.from) && reflect.DeepEqual(op.from, op.path[:len(op.from)]) {
+			return meta.Object{}, fmt.Errorf("%w: '%s' can not be moved into itself", ErrPatchFailed, pointer(op.from))
+		}
+		val, err := getAt(tree, op.from)
+		if err != nil {
+			return meta.Object{}, err
+		}
+		tree, err = removeAt(tree, op.from)
+		if err != nil {
+			return meta.Object{}, err
+		}
+		return addAt(tree, op.path, val)
+	case patchCopy:
+		val, err := getAt(tree, op.from)
+		if err != nil {
+			return meta.Object{}, err
+		}
+		return addAt(tree, op.path, val.Clone())
+	}
+
+	// patchTest
+	val, err := getAt(tree, op.path)
+	if err != nil {
+		return meta.Object{}, err
+	}
+	if !reflect.DeepEqual(normalizeJSON(val.ToRaw()), normalizeJSON(op.value)) {
+		return meta.Object{}, fmt.Errorf("%w: test of '%s' failed", ErrPatchFailed, pointer(op.path))
+	}
+	return tree, nil
+}
+
+// normalizeJSON converts the value to the form produced by decoding JSON so
+// values from different sources can be compared.
+func normalizeJSON(v any) any {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var rv any
+	if err := dec.Decode(&rv); err != nil {
+		return v
+	}
+	return rv
+}
+
+func isMapObject(obj meta.Object) bool {
+	return obj.Map != nil && obj.Array == nil
+}
+
+func isArrayObject(obj meta.Object) bool {
+	return obj.Array != nil
+}
+
+// arrayIndex converts the token into an index that must be in the range
+// [0, max].
+func arrayIndex(token string, max int) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || max < idx {
+		return 0, false
+	}
+	return idx, true
+}
+
+// getAt returns the Object found at the path.
+func getAt(tree meta.Object, path []string) (meta.Object, error) {
+	cur := tree
+	for i, token := range path {
+		found := false
+		switch {
+		case isArrayObject(cur):
+			var idx int
+			idx, found = arrayIndex(token, len(cur.Array)-1)
+			if found {
+				cur = cur.Array[idx]
+			}
+		case isMapObject(cur):
+			cur, found = cur.Map[token]
+		}
+
+		if !found {
+			return meta.Object{}, fmt.Errorf("%w: %w '%s'", ErrPatchFailed, meta.ErrNotFound, pointer(path[:i+1]))
+		}
+	}
+
+	return cur, nil
+}
+
+// update makes a copy of the containers along the path to the parent of the
+// last token in the path and calls fn with the copy of the parent.
+func update(tree meta.Object, path, full []string, fn func(parent meta.Object, token string) (meta.Object, error)) (meta.Object, error) {
+	if len(path) == 1 {
+		return fn(tree, path[0])
+	}
+
+	notFound := fmt.Errorf("%w: %w '%s'", ErrPatchFailed, meta.ErrNotFound,
+		pointer(full[:len(full)-len(path)+1]))
+
+	switch {
+	case isArrayObject(tree):
+		idx, ok := arrayIndex(path[0], len(tree.Array)-1)
+		if !ok {
+			return meta.Object{}, notFound
+		}
+		child, err := update(tree.Array[idx], path[1:], full, fn)
+		if err != nil {
+			return meta.Object{}, err
+		}
+		tree.Array = append([]meta.Object{}, tree.Array...)
+		tree.Array[idx] = child
+		return tree, nil
+	case isMapObject(tree):
+		existing, found := tree.Map[path[0]]
+		if !found {
+			return meta.Object{}, notFound
+		}
+		child, err := update(existing, path[1:], full, fn)
+		if err != nil {
+			return meta.Object{}, err
+		}
+		m := make(map[string]meta.Object, len(tree.Map))
+		for k, v := range tree.Map {
+			m[k] = v
+		}
+		m[path[0]] = child
+		tree.Map = m
+		return tree, nil
+	}
+
+	return meta.Object{}, notFound
+}
+
+// addAt adds the value at the path following the RFC 6902 'add' rules.
+func addAt(tree meta.Object, path []string, val meta.Object) (meta.Object, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+
+	return update(tree, path, path, func(parent meta.Object, token string) (meta.Object, error) {
+		switch {
+		case isArrayObject(parent):
+			idx := len(parent.Array)
+			if token != "-" {
+				var ok bool
+				idx, ok = arrayIndex(token, len(parent.Array))
+				if !ok {
+					return meta.Object{}, fmt.Errorf("%w: %w '%s'", ErrPatchFailed, meta.ErrArrayOutOfBounds, pointer(path))
+				}
+			}
+			array := make([]meta.Object, 0, len(parent.Array)+1)
+			array = append(array, parent.Array[:idx]...)
+			array = append(array, val)
+			parent.Array = append(array, parent.Array[idx:]...)
+			return parent, nil
+		case isMapObject(parent):
+			return withKey(parent, token, &val), nil
+		}
+		return meta.Object{}, fmt.Errorf("%w: %w '%s'", ErrPatchFailed, meta.ErrNotFound, pointer(path[:len(path)-1]))
+	})
+}
+
+// removeAt removes the value at the path.
+func removeAt(tree meta.Object, path []string) (meta.Object, error) {
+	return update(tree, path, path, func(parent meta.Object, token string) (meta.Object, error) {
+		switch {
+		case isArrayObject(parent):
+			if idx, ok := arrayIndex(token, len(parent.Array)-1); ok {
+				array := make([]meta.Object, 0, len(parent.Array)-1)
+				array = append(array, parent.Array[:idx]...)
+				parent.Array = append(array, parent.Array[idx+1:]...)
+				return parent, nil
+			}
+		case isMapObject(parent):
+			if _, found := parent.Map[token]; found {
+				return withKey(parent, token, nil), nil
+			}
+		}
+		return meta.Object{}, fmt.Errorf("%w: %w '%s'", ErrPatchFailed, meta.ErrNotFound, pointer(path))
+	})
+}
+
+// replaceAt replaces the existing value at the path.
+func replaceAt(tree meta.Object, path []string, val meta.Object) (meta.Object, error) {
+	if len(path) == 0 {
+		return val, nil
+	}
+
+	return update(tree, path, path, func(parent meta.Object, token string) (meta.Object, error) {
+		if isArrayObject(parent) {
+			idx, _ := arrayIndex(token, len(parent.Array)-1)
+			parent.Array = append([]meta.Object{}, parent.Array...)
+			parent.Array[idx] = val
+			return parent, nil
+		}
+		return withKey(parent, token, &val), nil
+	})
+}
+
+// withKey returns a copy of the map Object with the key set to the value, or
+// removed if the value is nil.
+func withKey(obj meta.Object, key string, val *meta.Object) meta.Object {
+	m := make(map[string]meta.Object, len(obj.Map)+1)
+	for k, v := range obj.Map {
+		m[k] = v
+	}
+
+	if val == nil {
+		delete(m, key)
+	} else {
+		m[key] = *val
+	}
+
+	obj.Map = m
+	return obj
+}
@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddPatch(t *testing.T) {
+	base := `{
+		"server": {"host":"a", "port":80, "tls":{"cert":"x"}},
+		"list": ["a", "b"],
+		"a/b": {"c~d": 1}
+	}`
+
+	tests := []struct {
+		description string
+		patch       string
+		expected    map[string]any
+		expectedErr error
+		newErr      error
+	}{
+		{
+			description: "A merge patch.",
+			patch:       `{"server":{"port":8080, "tls":null, "extra":{"a":"b"}}, "list":["c"]}`,
+			expected: map[string]any{
+				"server": map[string]any{
+					"host":  "a",
+					"port":  8080,
+					"extra": map[string]any{"a": "b"},
+				},
+				"list": []any{"c"},
+				"a/b":  map[string]any{"c~d": json.Number("1")},
+			},
+		}, {
+			description: "A merge patch that replaces a value with a map.",
+			patch:       `{"list":{"a":"b"}, "missing":null}`,
+			expected: map[string]any{
+				"server": map[string]any{
+					"host": "a",
+					"port": json.Number("80"),
+					"tls":  map[string]any{"cert": "x"},
+				},
+				"list": map[string]any{"a": "b"},
+				"a/b":  map[string]any{"c~d": json.Number("1")},
+			},
+		}, {
+			description: "A JSON patch with every operation.",
+			patch: `[
+				{"op":"test",    "path":"/server/port", "value":80},
+				{"op":"replace", "path":"/server/port", "value":9090},
+				{"op":"remove",  "path":"/server/tls"},
+				{"op":"add",     "path":"/list/-", "value":"d"},
+				{"op":"add",     "path":"/list/0", "value":"z"},
+				{"op":"remove",  "path":"/list/1"},
+				{"op":"replace", "path":"/list/1", "value":"y"},
+				{"op":"copy",    "from":"/server/host", "path":"/copied"},
+				{"op":"move",    "from":"/a~1b/c~0d", "path":"/moved"},
+				{"op":"add",     "path":"/server/new", "value":{"k":"v"}}
+			]`,
+			expected: map[string]any{
+				"server": map[string]any{
+					"host": "a",
+					"port": 9090,
+					"new":  map[string]any{"k": "v"},
+				},
+				"list":   []any{"z", "y", "d"},
+				"a/b":    nil,
+				"copied": "a",
+				"moved":  json.Number("1"),
+			},
+		}, {
+			description: "A JSON patch that fails a test.",
+			patch:       `[{"op":"test", "path":"/server/port", "value":81}]`,
+			expectedErr: ErrPatchFailed,
+		}, {
+			description: "A JSON patch with a missing path.",
+			patch:       `[{"op":"replace", "path":"/server/missing", "value":81}]`,
+			expectedErr: meta.ErrNotFound,
+		}, {
+			description: "A JSON patch with a missing parent.",
+			patch:       `[{"op":"add", "path":"/missing/key", "value":81}]`,
+			expectedErr: meta.ErrNotFound,
+		}, {
+			description: "A JSON patch below a value.",
+			patch:       `[{"op":"add", "path":"/server/host/key", "value":81}]`,
+			expectedErr: ErrPatchFailed,
+		}, {
+			description: "A JSON patch with an index out of bounds.",
+			patch:       `[{"op":"add", "path":"/list/3", "value":"x"}]`,
+			expectedErr: meta.ErrArrayOutOfBounds,
+		}, {
+			description: "A JSON patch removing a missing array index.",
+			patch:       `[{"op":"remove", "path":"/list/01"}]`,
+			expectedErr: meta.ErrNotFound,
+		}, {
+			description: "A JSON patch moving a map into itself.",
+			patch:       `[{"op":"move", "from":"/server", "path":"/server/tls/server"}]`,
+			expectedErr: ErrPatchFailed,
+		}, {
+			description: "A JSON patch copying a missing value.",
+			patch:       `[{"op":"copy", "from":"/missing", "path":"/server"}]`,
+			expectedErr: meta.ErrNotFound,
+		}, {
+			description: "A patch that is not valid JSON.",
+			patch:       `{`,
+			newErr:      ErrInvalidInput,
+		}, {
+			description: "A patch that is not an object or array.",
+			patch:       `"string"`,
+			newErr:      ErrInvalidInput,
+		}, {
+			description: "A JSON patch operation that is not an object.",
+			patch:       `["add"]`,
+			newErr:      ErrInvalidInput,
+		}, {
+			description: "A JSON patch with an unsupported operation.",
+			patch:       `[{"op":"invalid", "path":"/a"}]`,
+			newErr:      ErrInvalidInput,
+		}, {
+			description: "A JSON patch without a path.",
+			patch:       `[{"op":"remove"}]`,
+			newErr:      ErrInvalidInput,
+		}, {
+			description: "A JSON patch with an invalid path.",
+			patch:       `[{"op":"remove", "path":"a"}]`,
+			newErr:      ErrInvalidInput,
+		}, {
+			description: "A JSON patch without a value.",
+			patch:       `[{"op":"add", "path":"/a"}]`,
+			newErr:      ErrInvalidInput,
+		}, {
+			description: "A JSON patch without a from.",
+			patch:       `[{"op":"copy", "path":"/a"}]`,
+			newErr:      ErrInvalidInput,
+		}, {
+			description: "A JSON patch that removes the root.",
+			patch:       `[{"op":"remove", "path":""}]`,
+			newErr:      ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				AddBuffer("1.json", []byte(base)),
+				AddPatch("2.patch", []byte(tc.patch)),
+			)
+
+			if tc.newErr != nil {
+				assert.ErrorIs(err, tc.newErr)
+				assert.Nil(cfg)
+				return
+			}
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+			require.NoError(err)
+
+			var got map[string]any
+			err = cfg.Unmarshal(Root, &got)
+			require.NoError(err)
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestAddPatch_Sorting(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg, err := New(
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		AddBuffer("1.json", []byte(`{"port":80}`)),
+		AddPatch("2.patch", []byte(`[{"op":"replace", "path":"/port", "value":8080}]`)),
+		AddBuffer("3.json", []byte(`{"host":"a"}`)),
+	)
+	require.NoError(err)
+	assert.Equal([]string{"1.json", "2.patch", "3.json"}, cfg.Snapshot().Records())
+
+	steps, err := cfg.ExplainKey("port")
+	require.NoError(err)
+	require.Equal(2, len(steps))
+	assert.Equal("patch", steps[1].Command)
+	assert.Equal(8080, steps[1].After.ToRaw())
+	assert.Equal([]meta.Origin{{File: "2.patch"}}, steps[1].After.Origins)
+
+	_, err = New(AddPatch("", []byte(`{}`)))
+	assert.ErrorIs(err, ErrInvalidInput)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/goschtalt/goschtalt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEndToEnd(t *testing.T) {
@@ -178,3 +179,60 @@ func TestEndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func TestEndToEndDefaults(t *testing.T) {
+	type server struct {
+		Host    string        `goschtalt:"host,default=localhost"`
+		Timeout time.Duration `default:"5s"`
+	}
+	type withDefaults struct {
+		D      time.Duration `goschtalt:"D,default=1m"`
+		IP     net.IP        `default:"127.0.0.1"`
+		I      int           `goschtalt:",default=8080"`
+		T      time.Time     `default:"2022-08-15"`
+		Name   string
+		Server server
+	}
+
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg, err := goschtalt.New(
+		goschtalt.AutoCompile(),
+		goschtalt.DefaultUnmarshalOptions(
+			DurationUnmarshal(),
+			TextUnmarshal(AllButTime),
+			TimeUnmarshal("2006-01-02"),
+		),
+		goschtalt.AddValue("rec", goschtalt.Root, map[string]any{
+			"Name": "name",
+			"I":    99,
+		}),
+	)
+	require.NoError(err)
+
+	got, err := goschtalt.Unmarshal[withDefaults](cfg, goschtalt.Root,
+		goschtalt.Strictness(goschtalt.COMPLETE))
+	require.NoError(err)
+	assert.Equal(withDefaults{
+		D:    time.Minute,
+		IP:   net.ParseIP("127.0.0.1"),
+		I:    99,
+		T:    time.Date(2022, time.August, 15, 0, 0, 0, 0, time.UTC),
+		Name: "name",
+		Server: server{
+			Host:    "localhost",
+			Timeout: 5 * time.Second,
+		},
+	}, got)
+
+	// Without the adapters the defaults can't be converted.
+	cfg, err = goschtalt.New(
+		goschtalt.AutoCompile(),
+		goschtalt.AddValue("rec", goschtalt.Root, map[string]any{}),
+	)
+	require.NoError(err)
+
+	_, err = goschtalt.Unmarshal[withDefaults](cfg, goschtalt.Root)
+	assert.Error(err)
+}
@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package aesgcm provides a goschtalt.Decryptor that decrypts the configuration
+// values marked with the ((encrypted)) command using AES-GCM.
+//
+// The key is the base64 (standard encoding) form of a 16, 24 or 32 byte AES
+// key.  The encrypted values are the base64 (standard encoding) form of the
+// nonce followed by the sealed ciphertext, as produced by [Encrypt].
+package aesgcm
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/goschtalt/goschtalt"
+)
+
+var (
+	// ErrInvalidKey is returned when the key can't be read or isn't a valid
+	// base64 encoded AES key.
+	ErrInvalidKey = errors.New("invalid key")
+
+	// ErrInvalidCiphertext is returned when an encrypted value isn't valid
+	// base64 or can't be decrypted with the key.
+	ErrInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+// KeyFile provides the option to decrypt the encrypted values using the key
+// found in the file.  The file is read each time a value is decrypted so the
+// key can be rotated.
+func KeyFile(fsys fs.FS, filename string) goschtalt.Option {
+	return goschtalt.WithDecryptor(&decryptor{
+		key: func() (string, error) {
+			b, err := fs.ReadFile(fsys, filename)
+			if err != nil {
+				return "", fmt.Errorf("%w: %w", ErrInvalidKey, err)
+			}
+			return string(b), nil
+		},
+	}, "aesgcm.KeyFile")
+}
+
+// KeyEnv provides the option to decrypt the encrypted values using the key
+// found in the environment variable.  The environment variable is read each
+// time a value is decrypted.
+func KeyEnv(name string) goschtalt.Option {
+	return goschtalt.WithDecryptor(&decryptor{
+		key: func() (string, error) {
+			key, found := os.LookupEnv(name)
+			if !found {
+				return "", fmt.Errorf("%w: environment variable '%s' is not set", ErrInvalidKey, name)
+			}
+			return key, nil
+		},
+	}, "aesgcm.KeyEnv")
+}
+
+// GenerateKey returns a new random 32 byte key in the form used by [KeyFile],
+// [KeyEnv] and [Encrypt].
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Encrypt encrypts the plaintext using the key and returns the value to place
+// in the configuration file with the ((encrypted)) command.
+func Encrypt(key, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+type decryptor struct {
+	key func() (string, error)
+
+	// The cipher is only rebuilt when the key changes.
+	mutex   sync.Mutex
+	lastKey string
+	gcm     cipher.AEAD
+}
+
+var _ goschtalt.Decryptor = (*decryptor)(nil)
+
+func (d *decryptor) Decrypt(_ context.Context, ciphertext string) (string, error) {
+	gcm, err := d.cipher()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(ciphertext))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+	}
+
+	size := gcm.NonceSize()
+	if len(data) < size {
+		return "", fmt.Errorf("%w: too short", ErrInvalidCiphertext)
+	}
+
+	plaintext, err := gcm.Open(nil, data[:size], data[size:], nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+	}
+
+	return string(plaintext), nil
+}
+
+// cipher returns the AES-GCM cipher for the present key.
+func (d *decryptor) cipher() (cipher.AEAD, error) {
+	key, err := d.key()
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.gcm != nil && d.lastKey == key {
+		return d.gcm, nil
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	d.lastKey = key
+	d.gcm = gcm
+	return gcm, nil
+}
+
+// newGCM builds the AES-GCM cipher from the base64 encoded key.
+func newGCM(key string) (cipher.AEAD, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package aesgcm
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goschtalt/goschtalt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndToEnd(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	other, err := GenerateKey()
+	require.NoError(t, err)
+
+	ciphertext, err := Encrypt(key, "hunter2")
+	require.NoError(t, err)
+
+	tests := []struct {
+		description string
+		opt         goschtalt.Option
+		env         string
+		value       string
+		expectErr   error
+	}{
+		{
+			description: "A key from a file.",
+			opt:         KeyFile(fstest.MapFS{"key": {Data: []byte(key + "\n")}}, "key"),
+			value:       ciphertext,
+		}, {
+			description: "A key from an environment variable.",
+			opt:         KeyEnv("AESGCM_TEST_KEY"),
+			env:         key,
+			value:       ciphertext,
+		}, {
+			description: "A missing key file.",
+			opt:         KeyFile(fstest.MapFS{}, "key"),
+			value:       ciphertext,
+			expectErr:   ErrInvalidKey,
+		}, {
+			description: "A missing environment variable.",
+			opt:         KeyEnv("AESGCM_TEST_MISSING_KEY"),
+			value:       ciphertext,
+			expectErr:   ErrInvalidKey,
+		}, {
+			description: "The wrong key.",
+			opt:         KeyEnv("AESGCM_TEST_KEY"),
+			env:         other,
+			value:       ciphertext,
+			expectErr:   ErrInvalidCiphertext,
+		}, {
+			description: "A key that is the wrong size.",
+			opt:         KeyEnv("AESGCM_TEST_KEY"),
+			env:         base64.StdEncoding.EncodeToString([]byte("short")),
+			value:       ciphertext,
+			expectErr:   ErrInvalidKey,
+		}, {
+			description: "A key that isn't base64.",
+			opt:         KeyEnv("AESGCM_TEST_KEY"),
+			env:         "!!",
+			value:       ciphertext,
+			expectErr:   ErrInvalidKey,
+		}, {
+			description: "A value that isn't base64.",
+			opt:         KeyEnv("AESGCM_TEST_KEY"),
+			env:         key,
+			value:       "!!",
+			expectErr:   ErrInvalidCiphertext,
+		}, {
+			description: "A value that is too short.",
+			opt:         KeyEnv("AESGCM_TEST_KEY"),
+			env:         key,
+			value:       base64.StdEncoding.EncodeToString([]byte("short")),
+			expectErr:   ErrInvalidCiphertext,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			if tc.env != "" {
+				t.Setenv("AESGCM_TEST_KEY", tc.env)
+			}
+
+			cfg, err := goschtalt.New(
+				tc.opt,
+				goschtalt.AddValue("record", goschtalt.Root, map[string]any{
+					"password((encrypted))": tc.value,
+				}),
+				goschtalt.AutoCompile(),
+			)
+			if tc.expectErr != nil {
+				assert.ErrorIs(err, tc.expectErr)
+				assert.ErrorIs(err, goschtalt.ErrDecryptFailed)
+				assert.NotContains(err.Error(), "hunter2")
+				return
+			}
+			require.NoError(err)
+
+			got, err := goschtalt.Unmarshal[goschtalt.Secret[string]](cfg, "password")
+			require.NoError(err)
+			assert.Equal("hunter2", got.Reveal())
+
+			explain := cfg.Explain()
+			require.Len(explain.Decryptions, 1)
+			assert.True(strings.HasPrefix(explain.Decryptions[0], "'password' from record"))
+			assert.NotContains(explain.String(), "hunter2")
+		})
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, err := GenerateKey()
+	require.NoError(err)
+
+	a, err := Encrypt(key, "plaintext")
+	require.NoError(err)
+	b, err := Encrypt(key, "plaintext")
+	require.NoError(err)
+
+	// The nonce makes each encryption unique.
+	assert.NotEqual(a, b)
+
+	current := key
+	d := decryptor{
+		key: func() (string, error) { return current, nil },
+	}
+	got, err := d.Decrypt(context.Background(), a)
+	require.NoError(err)
+	assert.Equal("plaintext", got)
+
+	// The cipher is reused until the key changes.
+	gcm := d.gcm
+	got, err = d.Decrypt(context.Background(), b)
+	require.NoError(err)
+	assert.Equal("plaintext", got)
+	assert.Same(gcm, d.gcm)
+
+	rotated, err := GenerateKey()
+	require.NoError(err)
+	c, err := Encrypt(rotated, "rotated")
+	require.NoError(err)
+
+	current = rotated
+	got, err = d.Decrypt(context.Background(), c)
+	require.NoError(err)
+	assert.Equal("rotated", got)
+
+	_, err = d.Decrypt(context.Background(), a)
+	assert.ErrorIs(err, ErrInvalidCiphertext)
+
+	_, err = Encrypt("!!", "plaintext")
+	assert.ErrorIs(err, ErrInvalidKey)
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import "strconv"
+
+// IsEncrypted returns true if the Object was marked as encrypted and has not
+// been decrypted yet.
+func (obj Object) IsEncrypted() bool {
+	return obj.encrypted
+}
+
+// Decrypt builds a copy of the tree where the value of each Object marked as
+// encrypted is replaced by the result of the decrypt function.  The decrypted
+// Objects remain secret.  The path to the Object (map keys and array indexes)
+// is provided to the decrypt function.
+func (obj Object) Decrypt(decrypt func(path []string, obj Object) (any, error)) (Object, error) {
+	return obj.decrypt(nil, decrypt)
+}
+
+func (obj Object) decrypt(path []string, decrypt func([]string, Object) (any, error)) (Object, error) {
+	if obj.encrypted {
+		val, err := decrypt(append([]string{}, path...), obj)
+		if err != nil {
+			return Object{}, err
+		}
+		obj.Value = val
+		obj.encrypted = false
+		obj.secret = true
+		return obj, nil
+	}
+
+	switch obj.Kind() {
+	case Array:
+		array := make([]Object, len(obj.Array))
+		for i, val := range obj.Array {
+			v, err := val.decrypt(append(path, strconv.Itoa(i)), decrypt)
+			if err != nil {
+				return Object{}, err
+			}
+			array[i] = v
+		}
+		obj.Array = array
+	case Map:
+		m := make(map[string]Object, len(obj.Map))
+		for key, val := range obj.Map {
+			v, err := val.decrypt(append(path, key), decrypt)
+			if err != nil {
+				return Object{}, err
+			}
+			m[key] = v
+		}
+		obj.Map = m
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecrypt(t *testing.T) {
+	errTest := errors.New("test error")
+	tests := []struct {
+		description string
+		in          []string
+		expected    any
+		paths       []string
+		fail        bool
+		expectedErr error
+	}{
+		{
+			description: "Nothing is encrypted.",
+			in:          []string{`{"a":"b"}`},
+			expected:    map[string]any{"a": "b"},
+		}, {
+			description: "Encrypted values are decrypted.",
+			in: []string{
+				`{"a((encrypted))":"b", "c":[{"d((encrypted, keep))":"e"}]}`,
+			},
+			expected: map[string]any{
+				"a": "plain b",
+				"c": []any{map[string]any{"d": "plain e"}},
+			},
+			paths: []string{"a", "c.0.d"},
+		}, {
+			description: "Replaced encrypted values are not decrypted.",
+			in: []string{
+				`{"a((encrypted))":"b", "c((encrypted))":"d"}`,
+				`{"a":"x", "c((keep))":"y"}`,
+			},
+			expected: map[string]any{
+				"a": "x",
+				"c": "plain d",
+			},
+			paths: []string{"c"},
+		}, {
+			description: "The decryption fails.",
+			in:          []string{`{"a((encrypted))":"b"}`},
+			fail:        true,
+			expectedErr: errTest,
+		}, {
+			description: "Only values can be encrypted.",
+			in:          []string{`{"a((encrypted))":{"b":"c"}}`},
+			expectedErr: ErrInvalidCommand,
+		}, {
+			description: "Encrypted can only be present once.",
+			in:          []string{`{"a((encrypted encrypted))":"b"}`},
+			expectedErr: ErrInvalidCommand,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			tree, err := decode(tc.in[0]).ResolveCommands()
+			for _, in := range tc.in[1:] {
+				require.NoError(err)
+				tree, err = tree.Merge(decode(in))
+			}
+			if err == nil {
+				tree, err = tree.Decrypt(func(path []string, obj Object) (any, error) {
+					assert.True(obj.IsEncrypted())
+					if tc.fail {
+						return nil, errTest
+					}
+					got := strings.Join(path, ".")
+					assert.Contains(tc.paths, got)
+					return "plain " + obj.Value.(string), nil
+				})
+			}
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(err)
+			assert.Equal(tc.expected, tree.ToRaw())
+			for _, path := range tc.paths {
+				obj, err := tree.Fetch(strings.Split(path, "."), ".")
+				require.NoError(err)
+				assert.False(obj.IsEncrypted())
+				assert.True(obj.IsSecret())
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"sort"
+	"strings"
+)
+
+// Deprecation describes the use of a key that is marked as deprecated.
+type Deprecation struct {
+	Key     []string // The path to the deprecated key.
+	Origins []Origin // The origins of the value using the deprecated key.
+}
+
+// IsDeprecated returns true if the key of the Object was marked as deprecated.
+func (obj Object) IsDeprecated() bool {
+	return obj.deprecated
+}
+
+// Deprecations returns the uses of the keys marked as deprecated in the tree by
+// the next tree that will be merged with it.  The keys in the next tree may
+// contain commands.  The Deprecations are sorted by key.
+func (obj Object) Deprecations(next Object) []Deprecation {
+	return obj.deprecationsOf(nil, next)
+}
+
+func (obj Object) deprecationsOf(path []string, next Object) []Deprecation {
+	if obj.Kind() != Map || next.Kind() != Map {
+		return nil
+	}
+
+	keys := make([]string, 0, len(next.Map))
+	for key := range next.Map {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var rv []Deprecation
+	for _, key := range keys {
+		cmd, err := getCmd(key)
+		if err != nil {
+			// The merge reports the invalid command.
+			continue
+		}
+
+		existing, found := obj.Map[cmd.final]
+		if !found {
+			continue
+		}
+
+		val := next.Map[key]
+		p := append(append([]string{}, path...), cmd.final)
+		if existing.deprecated {
+			rv = append(rv, Deprecation{
+				Key:     p,
+				Origins: val.Origins,
+			})
+			continue
+		}
+
+		rv = append(rv, existing.deprecationsOf(p, val)...)
+	}
+
+	return rv
+}
+
+// deprecatedPaths returns the paths of all the deprecated Objects found in the
+// tree.  Only maps are traversed since array elements don't have keys.
+func (obj Object) deprecatedPaths(path []string) [][]string {
+	var rv [][]string
+	if obj.deprecated {
+		rv = append(rv, append([]string{}, path...))
+	}
+
+	if obj.Kind() == Map {
+		for key, val := range obj.Map {
+			rv = append(rv, val.deprecatedPaths(append(path, key))...)
+		}
+	}
+	return rv
+}
+
+// markDeprecated marks the Objects at the paths that are still present in the
+// tree as deprecated.
+func markDeprecated(paths [][]string, tree Object) Object {
+	for _, path := range paths {
+		if obj, found := tree.at(path); found && !obj.deprecated {
+			obj.deprecated = true
+			tree = tree.with(path, obj)
+		}
+	}
+	return tree
+}
+
+// Alias moves the Object found at the from path to the to path in a tree where
+// the keys may still contain commands (a tree that has not been merged or had
+// the commands resolved).  The commands on the key at the from path are moved
+// with the Object.  If the tree already has an Object at the to path, it takes
+// precedence and the Object at the from path is dropped.  Maps left empty by
+// the move are removed.
+//
+// The altered tree, the origins of the Object found at the from path and if the
+// from path was found are returned.  The tree is not altered in place.
+func (obj Object) Alias(from, to []string) (Object, []Origin, bool) {
+	if len(from) == 0 || len(to) == 0 {
+		return obj, nil, false
+	}
+
+	rv, val, key, found := obj.removeKey(from)
+	if !found {
+		return obj, nil, false
+	}
+
+	cmd, _ := getCmd(key)
+	dest := append([]string{}, to...)
+	dest[len(dest)-1] += strings.TrimPrefix(key, cmd.final)
+
+	if tree, ok := rv.insertKey(dest, val); ok {
+		rv = tree
+	}
+
+	return rv, val.Origins, true
+}
+
+// findKey returns the key in the map that matches the ask once the commands
+// are ignored.  If more than one key matches, the first in sorted order is
+// returned.
+func (obj Object) findKey(ask string) (string, bool) {
+	if obj.Kind() != Map {
+		return "", false
+	}
+
+	want, err := getCmd(ask)
+	if err != nil {
+		return "", false
+	}
+
+	var rv string
+	var found bool
+	for key := range obj.Map {
+		cmd, err := getCmd(key)
+		if err != nil || cmd.final != want.final {
+			continue
+		}
+		if !found || key < rv {
+			rv, found = key, true
+		}
+	}
+	return rv, found
+}
+
+// removeKey returns a copy of the tree without the Object at the path, the
+// Object removed and the key it was found at.
+func (obj Object) removeKey(path []string) (Object, Object, string, bool) {
+	key, found := obj.findKey(path[0])
+	if !found {
+		return obj, Object{}, "", false
+	}
+
+	val := obj.Map[key]
+	m := make(map[string]Object, len(obj.Map))
+	for k, v := range obj.Map {
+		m[k] = v
+	}
+
+	if len(path) == 1 {
+		delete(m, key)
+		obj.Map = m
+		return obj, val, key, true
+	}
+
+	child, removed, raw, found := val.removeKey(path[1:])
+	if !found {
+		return obj, Object{}, "", false
+	}
+
+	if len(child.Map) == 0 {
+		delete(m, key)
+	} else {
+		m[key] = child
+	}
+	obj.Map = m
+
+	return obj, removed, raw, true
+}
+
+// insertKey returns a copy of the tree with the Object added at the path.  Maps
+// are created as needed.  If the path is already present or is blocked by an
+// Object that isn't a map, false is returned.
+func (obj Object) insertKey(path []string, val Object) (Object, bool) {
+	if len(obj.Array) > 0 || obj.Value != nil {
+		return obj, false
+	}
+
+	m := make(map[string]Object, len(obj.Map)+1)
+	for k, v := range obj.Map {
+		m[k] = v
+	}
+
+	key, found := obj.findKey(path[0])
+	if len(path) == 1 {
+		if found {
+			return obj, false
+		}
+		m[path[0]] = val
+		obj.Map = m
+		return obj, true
+	}
+
+	child := Object{Origins: val.Origins}
+	if found {
+		child = obj.Map[key]
+		if child.Kind() != Map {
+			return obj, false
+		}
+	} else {
+		key = path[0]
+	}
+
+	child, ok := child.insertKey(path[1:], val)
+	if !ok {
+		return obj, false
+	}
+	m[key] = child
+	obj.Map = m
+
+	return obj, true
+}
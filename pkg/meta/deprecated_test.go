@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecations(t *testing.T) {
+	origin := []Origin{{File: "next.json", Line: 2}}
+	tests := []struct {
+		description string
+		in          []string
+		next        map[string]any
+		expected    []Deprecation
+	}{
+		{
+			description: "Nothing is deprecated.",
+			in:          []string{`{"a":"b"}`},
+			next:        map[string]any{"a": "c"},
+		}, {
+			description: "A deprecated value is used.",
+			in:          []string{`{"a((deprecated))":"b", "c":"d"}`},
+			next:        map[string]any{"a((replace))": "c", "c": "e"},
+			expected: []Deprecation{
+				{Key: []string{"a"}, Origins: origin},
+			},
+		}, {
+			description: "Deprecated keys stay deprecated after being merged.",
+			in:          []string{`{"a":{"b((deprecated))":"c"}}`, `{"a":{"b":"d"}}`},
+			next:        map[string]any{"a": map[string]any{"b": "e", "x": "y"}},
+			expected: []Deprecation{
+				{Key: []string{"a", "b"}, Origins: origin},
+			},
+		}, {
+			description: "A deprecated map is used.",
+			in:          []string{`{"a((deprecated secret))":{"b":"c"}, "d((deprecated))":"e"}`},
+			next:        map[string]any{"a": map[string]any{"b": "x"}, "d((delete))": nil},
+			expected: []Deprecation{
+				{Key: []string{"a"}, Origins: origin},
+				{Key: []string{"d"}, Origins: origin},
+			},
+		}, {
+			description: "A deprecated key that isn't used.",
+			in:          []string{`{"a((deprecated))":"b"}`},
+			next:        map[string]any{"c": "d", "a((bad": "x"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			tree, err := decode(tc.in[0]).ResolveCommands()
+			require.NoError(err)
+			for _, in := range tc.in[1:] {
+				tree, err = tree.Merge(decode(in))
+				require.NoError(err)
+			}
+
+			next := ObjectFromRawWithOrigin(tc.next, origin)
+			assert.Equal(tc.expected, tree.Deprecations(next))
+		})
+	}
+}
+
+func TestDeprecatedIsKept(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tree, err := decode(`{"a((deprecated))":"b"}`).ResolveCommands()
+	require.NoError(err)
+	assert.True(tree.Map["a"].IsDeprecated())
+
+	tree, err = tree.Merge(decode(`{"a":"c"}`))
+	require.NoError(err)
+	assert.True(tree.Map["a"].IsDeprecated())
+	assert.Equal(map[string]any{"a": "c"}, tree.ToRaw())
+
+	tree, err = tree.Merge(decode(`{"a((delete))":null}`))
+	require.NoError(err)
+	tree, err = tree.Merge(decode(`{"a":"d"}`))
+	require.NoError(err)
+	assert.False(tree.Map["a"].IsDeprecated())
+}
+
+func TestAlias(t *testing.T) {
+	tests := []struct {
+		description string
+		in          string
+		from        []string
+		to          []string
+		expected    any
+		notFound    bool
+	}{
+		{
+			description: "A simple move.",
+			in:          `{"a":"b", "c":"d"}`,
+			from:        []string{"a"},
+			to:          []string{"x"},
+			expected:    map[string]any{"x": "b", "c": "d"},
+		}, {
+			description: "The commands are moved with the value.",
+			in:          `{"a((secret, replace))":{"b":"c"}}`,
+			from:        []string{"a"},
+			to:          []string{"x"},
+			expected:    map[string]any{"x((secret, replace))": map[string]any{"b": "c"}},
+		}, {
+			description: "A deep move into an existing map.",
+			in:          `{"a":{"b":{"c":"d"}}, "x((splice))":{"y":"z"}}`,
+			from:        []string{"a", "b", "c"},
+			to:          []string{"x", "c"},
+			expected:    map[string]any{"x((splice))": map[string]any{"y": "z", "c": "d"}},
+		}, {
+			description: "A move into new maps.",
+			in:          `{"a":{"b":"c", "d":"e"}}`,
+			from:        []string{"a", "b"},
+			to:          []string{"x", "y", "z"},
+			expected: map[string]any{
+				"a": map[string]any{"d": "e"},
+				"x": map[string]any{"y": map[string]any{"z": "c"}},
+			},
+		}, {
+			description: "The new key takes precedence.",
+			in:          `{"a":"b", "x((keep))":"y"}`,
+			from:        []string{"a"},
+			to:          []string{"x"},
+			expected:    map[string]any{"x((keep))": "y"},
+		}, {
+			description: "The new key is blocked by a value.",
+			in:          `{"a":"b", "x":"y"}`,
+			from:        []string{"a"},
+			to:          []string{"x", "z"},
+			expected:    map[string]any{"x": "y"},
+		}, {
+			description: "The old key isn't present.",
+			in:          `{"a":{"b":"c"}}`,
+			from:        []string{"a", "x"},
+			to:          []string{"x"},
+			expected:    map[string]any{"a": map[string]any{"b": "c"}},
+			notFound:    true,
+		}, {
+			description: "The old key is the root.",
+			in:          `{"a":"b"}`,
+			to:          []string{"x"},
+			expected:    map[string]any{"a": "b"},
+			notFound:    true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			in := decode(tc.in)
+			before := in.ToRaw()
+
+			got, origins, found := in.Alias(tc.from, tc.to)
+			assert.Equal(tc.expected, got.ToRaw())
+			assert.Equal(!tc.notFound, found)
+			if found {
+				assert.NotNil(origins)
+			}
+
+			// The tree is not altered in place.
+			assert.Equal(before, in.ToRaw())
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ChangeKind describes how a leaf differs between two trees.
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota + 1
+	Removed
+	Changed
+)
+
+// String returns a useful representation for the kind of change.
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+	return "unknown"
+}
+
+// Change describes a single leaf difference found by Diff.
+type Change struct {
+	Path       []string   // The keys and array indexes to the leaf.
+	Kind       ChangeKind // How the leaf changed.
+	Old        any        // The value before the change (if present).
+	New        any        // The value after the change (if present).
+	OldOrigins []Origin   // The origins of the value before the change.
+	NewOrigins []Origin   // The origins of the value after the change.
+	Secret     bool       // If either value is secret; the values are redacted.
+}
+
+// Diff compares the obj tree (before) with the other tree (after) and returns
+// the leaf paths that were added, removed or changed.  The changes are ordered
+// by path with map keys sorted and array elements in index order.
+//
+// Secret Objects are treated as leaves and their values are reported as
+// 'REDACTED', so a change inside a secret map or array is reported as a change
+// of the entire secret Object.
+func (obj Object) Diff(other Object) []Change {
+	var changes []Change
+	obj.diff(other, []string{}, &changes)
+	return changes
+}
+
+func (obj Object) diff(other Object, path []string, changes *[]Change) {
+	if obj.secret || other.secret {
+		if !reflect.DeepEqual(obj.ToRaw(), other.ToRaw()) {
+			*changes = append(*changes, Change{
+				Path:       clonePath(path),
+				Kind:       Changed,
+				Old:        redactedText,
+				New:        redactedText,
+				OldOrigins: cloneOrigins(obj.Origins),
+				NewOrigins: cloneOrigins(other.Origins),
+				Secret:     true,
+			})
+		}
+		return
+	}
+
+	kind := obj.Kind()
+	if kind != other.Kind() {
+		obj.leaves(Removed, path, changes)
+		other.leaves(Added, path, changes)
+		return
+	}
+
+	switch kind {
+	case Array:
+		for i := 0; i < len(obj.Array) || i < len(other.Array); i++ {
+			next := append(path, strconv.Itoa(i))
+			switch {
+			case i >= len(other.Array):
+				obj.Array[i].leaves(Removed, next, changes)
+			case i >= len(obj.Array):
+				other.Array[i].leaves(Added, next, changes)
+			default:
+				obj.Array[i].diff(other.Array[i], next, changes)
+			}
+		}
+	case Map:
+		keys := make([]string, 0, len(obj.Map)+len(other.Map))
+		for key := range obj.Map {
+			keys = append(keys, key)
+		}
+		for key := range other.Map {
+			if _, found := obj.Map[key]; !found {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			next := append(path, key)
+			before, inBefore := obj.Map[key]
+			after, inAfter := other.Map[key]
+			switch {
+			case !inAfter:
+				before.leaves(Removed, next, changes)
+			case !inBefore:
+				after.leaves(Added, next, changes)
+			default:
+				before.diff(after, next, changes)
+			}
+		}
+	default:
+		if !reflect.DeepEqual(obj.Value, other.Value) {
+			*changes = append(*changes, Change{
+				Path:       clonePath(path),
+				Kind:       Changed,
+				Old:        obj.Value,
+				New:        other.Value,
+				OldOrigins: cloneOrigins(obj.Origins),
+				NewOrigins: cloneOrigins(other.Origins),
+			})
+		}
+	}
+}
+
+// leaves reports every leaf in the obj tree as the specified kind of change.
+func (obj Object) leaves(kind ChangeKind, path []string, changes *[]Change) {
+	if !obj.secret {
+		switch obj.Kind() {
+		case Array:
+			for i, val := range obj.Array {
+				val.leaves(kind, append(path, strconv.Itoa(i)), changes)
+			}
+			return
+		case Map:
+			keys := make([]string, 0, len(obj.Map))
+			for key := range obj.Map {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				obj.Map[key].leaves(kind, append(path, key), changes)
+			}
+			return
+		}
+	}
+
+	val := obj.Value
+	if obj.secret {
+		val = redactedText
+	}
+
+	change := Change{
+		Path:   clonePath(path),
+		Kind:   kind,
+		Secret: obj.secret,
+	}
+	if kind == Added {
+		change.New = val
+		change.NewOrigins = cloneOrigins(obj.Origins)
+	} else {
+		change.Old = val
+		change.OldOrigins = cloneOrigins(obj.Origins)
+	}
+
+	*changes = append(*changes, change)
+}
+
+func clonePath(path []string) []string {
+	return append([]string{}, path...)
+}
+
+func cloneOrigins(origins []Origin) []Origin {
+	if len(origins) == 0 {
+		return nil
+	}
+	return append([]Origin{}, origins...)
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	a := Origin{File: "a.yml", Line: 1}
+	b := Origin{File: "b.yml", Line: 2}
+
+	tests := []struct {
+		description string
+		before      Object
+		after       Object
+		secret      bool
+		expected    []Change
+	}{
+		{
+			description: "Identical trees.",
+			before:      decode(`{"a":{"b":[1,2]}, "c":"d"}`),
+			after:       decode(`{"a":{"b":[1,2]}, "c":"d"}`),
+		}, {
+			description: "Empty trees.",
+		}, {
+			description: "A changed value with origins.",
+			before:      ObjectFromRawWithOrigin(map[string]any{"a": "b"}, []Origin{a}),
+			after:       ObjectFromRawWithOrigin(map[string]any{"a": "c"}, []Origin{b}),
+			expected: []Change{
+				{
+					Path:       []string{"a"},
+					Kind:       Changed,
+					Old:        "b",
+					New:        "c",
+					OldOrigins: []Origin{a},
+					NewOrigins: []Origin{b},
+				},
+			},
+		}, {
+			description: "Added and removed keys are sorted.",
+			before:      decode(`{"z":"1", "b":{"c":"2", "d":"3"}}`),
+			after:       decode(`{"a":"1", "b":{"c":"2", "e":"3"}}`),
+			expected: []Change{
+				{Path: []string{"a"}, Kind: Added, New: "1"},
+				{Path: []string{"b", "d"}, Kind: Removed, Old: "3"},
+				{Path: []string{"b", "e"}, Kind: Added, New: "3"},
+				{Path: []string{"z"}, Kind: Removed, Old: "1"},
+			},
+		}, {
+			description: "Arrays are compared by index.",
+			before:      decode(`{"a":["x","y","z"]}`),
+			after:       decode(`{"a":["x","q"]}`),
+			expected: []Change{
+				{Path: []string{"a", "1"}, Kind: Changed, Old: "y", New: "q"},
+				{Path: []string{"a", "2"}, Kind: Removed, Old: "z"},
+			},
+		}, {
+			description: "A longer array.",
+			before:      decode(`{"a":["x"]}`),
+			after:       decode(`{"a":["x",{"b":"c"}]}`),
+			expected: []Change{
+				{Path: []string{"a", "1", "b"}, Kind: Added, New: "c"},
+			},
+		}, {
+			description: "The kind changes.",
+			before:      decode(`{"a":{"b":"c"}}`),
+			after:       decode(`{"a":"d"}`),
+			expected: []Change{
+				{Path: []string{"a", "b"}, Kind: Removed, Old: "c"},
+				{Path: []string{"a"}, Kind: Added, New: "d"},
+			},
+		}, {
+			description: "A changed secret is redacted.",
+			before:      decode(`{"pw((secret))":"old", "user":"bob"}`),
+			after:       decode(`{"pw((secret))":"new", "user":"bob"}`),
+			secret:      true,
+			expected: []Change{
+				{Path: []string{"pw"}, Kind: Changed, Old: "REDACTED", New: "REDACTED", Secret: true},
+			},
+		}, {
+			description: "An unchanged secret is not reported.",
+			before:      decode(`{"pw((secret))":"same"}`),
+			after:       decode(`{"pw((secret))":"same"}`),
+			secret:      true,
+		}, {
+			description: "A secret map is treated as a leaf.",
+			before:      decode(`{"db((secret))":{"user":"bob"}}`),
+			after:       decode(`{"db((secret))":{"user":"bob", "pw":"123"}}`),
+			secret:      true,
+			expected: []Change{
+				{Path: []string{"db"}, Kind: Changed, Old: "REDACTED", New: "REDACTED", Secret: true},
+			},
+		}, {
+			description: "Added and removed secrets are redacted.",
+			before:      decode(`{"old((secret))":"abc"}`),
+			after:       decode(`{"new((secret))":{"a":"b"}}`),
+			secret:      true,
+			expected: []Change{
+				{Path: []string{"new"}, Kind: Added, New: "REDACTED", Secret: true},
+				{Path: []string{"old"}, Kind: Removed, Old: "REDACTED", Secret: true},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			before, after := tc.before, tc.after
+			if tc.secret {
+				var err error
+				before, err = before.ResolveCommands()
+				require.NoError(err)
+				after, err = after.ResolveCommands()
+				require.NoError(err)
+			}
+
+			got := before.Diff(after)
+
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestChangeKind_String(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("added", Added.String())
+	assert.Equal("removed", Removed.String())
+	assert.Equal("changed", Changed.String())
+	assert.Equal("unknown", ChangeKind(0).String())
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// IsFinal returns true if the Object was marked as final and can not be
+// changed by later merges.
+func (obj Object) IsFinal() bool {
+	return obj.locked
+}
+
+// ToFinal builds a copy of the tree where all the values and arrays are marked
+// as final.  Maps are not marked as final, so new keys can still be added to
+// them.
+func (obj Object) ToFinal() Object {
+	// Kind() reports empty maps as values, but they are still maps.
+	if obj.Map != nil {
+		m := make(map[string]Object, len(obj.Map))
+		for key, val := range obj.Map {
+			m[key] = val.ToFinal()
+		}
+		obj.Map = m
+		return obj
+	}
+
+	obj.locked = true
+	return obj
+}
+
+// EnforceFinal checks that the Objects marked as final in the tree are
+// unchanged in the result of altering the tree (by a patch for example).  The
+// result is returned with the final Objects restored, or an error wrapping
+// ErrFinal naming the origins of the final Object and the origins of the
+// change (by).  The keyDelimiter is used to join the keys in the error.
+//
+// The tree must not have been altered in place.
+func (obj Object) EnforceFinal(result Object, keyDelimiter string, by ...Origin) (Object, error) {
+	changedBy := Object{Origins: by}.OriginString()
+	return enforceFinal(obj.finals(nil), result, keyDelimiter, func([]string) string {
+		return changedBy
+	})
+}
+
+// final is an Object marked as final and where it was found.
+type final struct {
+	path []string
+	obj  Object
+}
+
+// finals returns a copy of all the final Objects found in the tree.  Only maps
+// are traversed since array elements don't have a stable path.
+func (obj Object) finals(path []string) []final {
+	if obj.locked {
+		return []final{{
+			path: append([]string{}, path...),
+			obj:  obj.Clone(),
+		}}
+	}
+
+	var rv []final
+	if obj.Kind() == Map {
+		for key, val := range obj.Map {
+			rv = append(rv, val.finals(append(path, key))...)
+		}
+	}
+	return rv
+}
+
+// enforceFinal makes sure each of the final Objects are unchanged in the
+// result.  The final Objects are restored so they remain final.
+func enforceFinal(finals []final, result Object, keyDelimiter string, changedBy func([]string) string) (Object, error) {
+	for _, f := range finals {
+		got, found := result.at(f.path)
+		if !found || !reflect.DeepEqual(f.obj.ToRaw(), got.ToRaw()) {
+			return Object{}, fmt.Errorf("%w: '%s' from %s was changed by %s",
+				ErrFinal, strings.Join(f.path, keyDelimiter), f.obj.OriginString(), changedBy(f.path))
+		}
+
+		result = result.with(f.path, f.obj)
+	}
+
+	return result, nil
+}
+
+// changedBy returns the origins of the deepest Object found along the path in
+// the tree being merged.
+func (obj Object) changedBy(path []string) string {
+	for i := len(path); i >= 0; i-- {
+		if found, _, ok := obj.Lookup(path[:i]); ok {
+			return found.OriginString()
+		}
+	}
+	return obj.OriginString()
+}
+
+// at returns the Object found by following the map keys in the path.
+func (obj Object) at(path []string) (Object, bool) {
+	for _, key := range path {
+		if obj.Kind() != Map {
+			return Object{}, false
+		}
+
+		var found bool
+		obj, found = obj.Map[key]
+		if !found {
+			return Object{}, false
+		}
+	}
+	return obj, true
+}
+
+// with returns the tree with the Object at the path replaced by val.  The path
+// must already exist in the tree.
+func (obj Object) with(path []string, val Object) Object {
+	if len(path) == 0 {
+		return val
+	}
+
+	m := make(map[string]Object, len(obj.Map))
+	for key, v := range obj.Map {
+		m[key] = v
+	}
+	m[path[0]] = m[path[0]].with(path[1:], val)
+	obj.Map = m
+
+	return obj
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeFinal(t *testing.T) {
+	tests := []struct {
+		description string
+		in          string
+		next        []string
+		expected    any
+		final       []string
+		expectedErr error
+	}{
+		{
+			description: "A final value that isn't changed.",
+			in:          `{"a((final))":"b", "c":"d"}`,
+			next:        []string{`{"c":"e"}`, `{"a":"b"}`},
+			expected:    map[string]any{"a": "b", "c": "e"},
+			final:       []string{"a"},
+		}, {
+			description: "A final map that isn't changed.",
+			in:          `{"a((final, secret))":{"b":"c"}}`,
+			next:        []string{`{"a((replace))":{"b":"c"}}`, `{"x((final))":["y"]}`},
+			expected:    map[string]any{"a": map[string]any{"b": "c"}, "x": []any{"y"}},
+			final:       []string{"a", "x"},
+		}, {
+			description: "A final value below a map.",
+			in:          `{"a":{"b((final))":"c"}}`,
+			next:        []string{`{"a":{"d":"e"}}`},
+			expected:    map[string]any{"a": map[string]any{"b": "c", "d": "e"}},
+			final:       []string{"a.b"},
+		}, {
+			description: "A final value is changed.",
+			in:          `{"a((final))":"b"}`,
+			next:        []string{`{"a":"c"}`},
+			expectedErr: ErrFinal,
+		}, {
+			description: "A final value is changed by keep.",
+			in:          `{"a((final))":"b"}`,
+			next:        []string{`{"a((keep))":"c"}`, `{"a":"c"}`},
+			expectedErr: ErrFinal,
+		}, {
+			description: "A final map has a key added.",
+			in:          `{"a((final))":{"b":"c"}}`,
+			next:        []string{`{"a":{"d":"e"}}`},
+			expectedErr: ErrFinal,
+		}, {
+			description: "A final array is appended to.",
+			in:          `{"a((final))":["b"]}`,
+			next:        []string{`{"a":["c"]}`},
+			expectedErr: ErrFinal,
+		}, {
+			description: "A final value is changed by replacing the parent.",
+			in:          `{"a":{"b((final))":"c"}}`,
+			next:        []string{`{"a((replace))":{"d":"e"}}`},
+			expectedErr: ErrFinal,
+		}, {
+			description: "A final value is deleted.",
+			in:          `{"a":{"b((final))":"c"}}`,
+			next:        []string{`{"a((delete))":null}`},
+			expectedErr: ErrFinal,
+		}, {
+			description: "A final value is cleared.",
+			in:          `{"a((final))":"b"}`,
+			next:        []string{`{"x((clear))":null}`},
+			expectedErr: ErrFinal,
+		}, {
+			description: "A duplicate final command.",
+			in:          `{}`,
+			next:        []string{`{"a((final final))":"b"}`},
+			expectedErr: ErrInvalidCommand,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			got, err := decode(tc.in).ResolveCommands()
+			require.NoError(err)
+
+			for _, next := range tc.next {
+				got, err = got.Merge(decode(next))
+				if err != nil {
+					break
+				}
+			}
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(err)
+			assert.Equal(tc.expected, got.ToRaw())
+			for _, key := range tc.final {
+				obj, err := got.Fetch(strings.Split(key, "."), ".")
+				require.NoError(err)
+				assert.True(obj.IsFinal(), key)
+			}
+		})
+	}
+}
+
+func TestToFinal(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tree := decode(`{"a":{"b":"c", "d":["e"]}}`).ToFinal()
+	assert.False(tree.IsFinal())
+	assert.False(tree.Map["a"].IsFinal())
+	assert.True(tree.Map["a"].Map["b"].IsFinal())
+	assert.True(tree.Map["a"].Map["d"].IsFinal())
+
+	// New keys can be added, but the final values can't be changed.
+	got, err := tree.Merge(decode(`{"a":{"x":"y"}}`))
+	require.NoError(err)
+	assert.Equal(map[string]any{"a": map[string]any{"b": "c", "d": []any{"e"}, "x": "y"}}, got.ToRaw())
+
+	_, err = got.Merge(decode(`{"a":{"b":"z"}}`))
+	assert.ErrorIs(err, ErrFinal)
+
+	// Empty maps are not final either.
+	tree = decode(`{"m":{}}`).ToFinal()
+	assert.False(tree.Map["m"].IsFinal())
+
+	got, err = tree.Merge(decode(`{"m":{"x":"y"}}`))
+	require.NoError(err)
+	assert.Equal(map[string]any{"m": map[string]any{"x": "y"}}, got.ToRaw())
+}
+
+func TestEnforceFinal(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	origin := Origin{File: "final.json", Line: 1}
+	tree, err := ObjectFromRawWithOrigin(map[string]any{
+		"a((final))": "b",
+		"c":          "d",
+	}, []Origin{origin}).ResolveCommands()
+	require.NoError(err)
+
+	// Unchanged final values are allowed and remain final.
+	changed := ObjectFromRaw(map[string]any{"a": "b", "c": "x"})
+	got, err := tree.EnforceFinal(changed, "/", Origin{File: "patch"})
+	require.NoError(err)
+	assert.Equal(map[string]any{"a": "b", "c": "x"}, got.ToRaw())
+	assert.True(got.Map["a"].IsFinal())
+	assert.Equal([]Origin{origin}, got.Map["a"].Origins)
+
+	changed = ObjectFromRaw(map[string]any{"a": "x"})
+	_, err = tree.EnforceFinal(changed, "/", Origin{File: "patch"})
+	assert.ErrorIs(err, ErrFinal)
+	assert.ErrorContains(err, "final.json:1")
+	assert.ErrorContains(err, "patch")
+}
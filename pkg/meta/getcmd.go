@@ -14,10 +14,14 @@ var (
 )
 
 type command struct {
-	full   string
-	cmd    string
-	secret bool
-	final  string
+	full       string
+	cmd        string
+	secret     bool
+	final      string
+	arg        string // The argument for commands that take one ('merge-by').
+	locked     bool   // If the 'final' command was found.
+	deprecated bool   // If the 'deprecated' command was found.
+	encrypted  bool   // If the 'encrypted' command was found.
 }
 
 // getCmd processes the input string and extracts the commands that my be
@@ -35,10 +39,23 @@ func getCmd(s string) (command, error) {
 		return cmd, nil
 	}
 
-	cmd.final = strings.TrimSpace(sub[1])
+	parsed, err := parseCmds(sub[2])
+	if err != nil {
+		return command{}, err
+	}
+
+	parsed.full = cmd.full
+	parsed.final = strings.TrimSpace(sub[1])
+
+	return parsed, nil
+}
 
-	// Get rid of ',' and take the interior of the (()) and split into fields.
-	inner := strings.ReplaceAll(sub[2], ",", " ")
+// parseCmds processes the commands found inside the (( )).
+func parseCmds(s string) (command, error) {
+	var cmd command
+
+	// Get rid of ',' and split into fields.
+	inner := strings.ReplaceAll(s, ",", " ")
 	list := strings.Fields(inner)
 
 	// Make sure commands are only a limited character set.
@@ -49,7 +66,8 @@ func getCmd(s string) (command, error) {
 	}
 
 	cmds := make([]string, 0, len(list))
-	for _, val := range list {
+	for i := 0; i < len(list); i++ {
+		val := list[i]
 		if cmdSecret == val {
 			// 'secret' can only show up once.
 			if cmd.secret {
@@ -59,6 +77,42 @@ func getCmd(s string) (command, error) {
 			continue
 		}
 
+		if cmdFinal == val {
+			// 'final' can only show up once.
+			if cmd.locked {
+				return command{}, ErrInvalidCommand
+			}
+			cmd.locked = true
+			continue
+		}
+
+		if cmdDeprecated == val {
+			// 'deprecated' can only show up once.
+			if cmd.deprecated {
+				return command{}, ErrInvalidCommand
+			}
+			cmd.deprecated = true
+			continue
+		}
+
+		if cmdEncrypted == val {
+			// 'encrypted' can only show up once.
+			if cmd.encrypted {
+				return command{}, ErrInvalidCommand
+			}
+			cmd.encrypted = true
+			continue
+		}
+
+		if cmdMergeBy == val {
+			// 'merge-by' must be followed by the name of the identity field.
+			i++
+			if i >= len(list) {
+				return command{}, ErrInvalidCommand
+			}
+			cmd.arg = list[i]
+		}
+
 		cmds = append(cmds, val)
 	}
 	if len(cmds) > 1 {
@@ -130,6 +130,60 @@ func TestMap_getCmd(t *testing.T) {
 				secret: true,
 				final:  "foo",
 			},
+		}, {
+			description: "A command with an argument.",
+			input:       "foo((merge-by name))",
+			expected: command{
+				full:  "foo((merge-by name))",
+				cmd:   "merge-by",
+				arg:   "name",
+				final: "foo",
+			},
+		}, {
+			description: "A command with an argument and secret.",
+			input:       "foo((secret, merge-by secret))",
+			expected: command{
+				full:   "foo((secret, merge-by secret))",
+				cmd:    "merge-by",
+				arg:    "secret",
+				secret: true,
+				final:  "foo",
+			},
+		}, {
+			description: "Final with a command and secret.",
+			input:       "foo((final, secret, keep))",
+			expected: command{
+				full:   "foo((final, secret, keep))",
+				cmd:    "keep",
+				secret: true,
+				locked: true,
+				final:  "foo",
+			},
+		}, {
+			description: "Invalid because final can only be present once.",
+			input:       "foo((final,final))",
+			expectedErr: ErrInvalidCommand,
+		}, {
+			description: "Deprecated with a command.",
+			input:       "foo((replace deprecated))",
+			expected: command{
+				full:       "foo((replace deprecated))",
+				cmd:        "replace",
+				deprecated: true,
+				final:      "foo",
+			},
+		}, {
+			description: "Invalid because deprecated can only be present once.",
+			input:       "foo((deprecated,deprecated))",
+			expectedErr: ErrInvalidCommand,
+		}, {
+			description: "Invalid because merge-by requires an argument.",
+			input:       "foo((secret, merge-by))",
+			expectedErr: ErrInvalidCommand,
+		}, {
+			description: "Invalid because merge-by only takes one argument.",
+			input:       "foo((merge-by name id))",
+			expectedErr: ErrInvalidCommand,
 		}, {
 			description: "Invalid because secret can only be present once.",
 			input:       "foo((secret,secret))",
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import "strconv"
+
+// Lookup finds the Object at the path described by the asks in a tree where
+// the keys may still contain commands (a tree that has not been merged or had
+// the commands resolved).  The merge command that applies to the Object when
+// the tree is merged is also returned.
+//
+// Commands on parent keys that replace, keep, fail or delete the parent apply
+// to the entire parent, so they take precedence over commands found further
+// down the path.  If the tree contains the 'clear' command, 'clear' is returned
+// even if the path is not found.  If no command applies, the default merge command for
+// the kind of Object is returned ('splice' for maps, 'append' for arrays and
+// 'replace' for values).
+func (obj Object) Lookup(asks []string) (Object, string, bool) {
+	return obj.LookupWithStrategy(asks, nil)
+}
+
+// LookupWithStrategy is the same as Lookup, except the Strategy is used to
+// determine the merge command for keys that don't have one.  Invalid strategies
+// are ignored.
+func (obj Object) LookupWithStrategy(asks []string, s Strategy) (Object, string, bool) {
+	inherited := ""
+	if obj.Clears() {
+		inherited = cmdClear
+	}
+
+	cur := obj
+	cmd := lookupStrategy(s, nil, command{}, obj)
+	for i, ask := range asks {
+		if inherited == "" {
+			switch cmd {
+			case cmdReplace, cmdKeep, cmdFail, cmdDelete:
+				inherited = cmd
+			}
+		}
+
+		found := false
+		switch cur.Kind() {
+		case Map:
+			for key, val := range cur.Map {
+				c, err := getCmd(key)
+				if err == nil && c.final == ask {
+					cur, cmd, found = val, lookupStrategy(s, asks[:i+1], c, val), true
+					break
+				}
+			}
+		case Array:
+			if inherited == "" {
+				inherited = cmd
+				if inherited == "" {
+					inherited = cmdAppend
+				}
+			}
+
+			idx, err := strconv.Atoi(ask)
+			if err == nil && 0 <= idx && idx < len(cur.Array) {
+				cur, cmd, found = cur.Array[idx], "", true
+			}
+		}
+
+		if !found {
+			return Object{}, inherited, false
+		}
+	}
+
+	if inherited != "" {
+		return cur, inherited, true
+	}
+	if cmd != "" {
+		return cur, cmd, true
+	}
+
+	switch cur.Kind() {
+	case Map:
+		return cur, cmdSplice, true
+	case Array:
+		return cur, cmdAppend, true
+	}
+
+	return cur, cmdReplace, true
+}
+
+// Clears returns true if the tree contains the 'clear' command, which removes
+// everything in the existing tree when the tree is merged.
+func (obj Object) Clears() bool {
+	for key := range obj.Map {
+		cmd, err := getCmd(key)
+		if err == nil && cmd.cmd == cmdClear {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookupStrategy returns the merge command provided by the strategy or the
+// command from the key if the strategy doesn't apply.
+func lookupStrategy(s Strategy, path []string, c command, val Object) string {
+	str := strategyFor(s, path, c, val)
+	if str == "" || ValidStrategy(val.Kind(), str) != nil {
+		return c.cmd
+	}
+
+	parsed, _ := parseCmds(str)
+	return parsed.cmd
+}
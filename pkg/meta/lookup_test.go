@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		description string
+		in          string
+		asks        []string
+		expected    any
+		cmd         string
+		notFound    bool
+	}{
+		{
+			description: "The root.",
+			in:          `{"a":"b"}`,
+			expected:    map[string]any{"a": "b"},
+			cmd:         "splice",
+		}, {
+			description: "A value with no command.",
+			in:          `{"a":{"b":"c"}}`,
+			asks:        []string{"a", "b"},
+			expected:    "c",
+			cmd:         "replace",
+		}, {
+			description: "A value with a command.",
+			in:          `{"a":{"b((keep))":"c"}}`,
+			asks:        []string{"a", "b"},
+			expected:    "c",
+			cmd:         "keep",
+		}, {
+			description: "A value with a secret command.",
+			in:          `{"a":{"b((secret))":"c"}}`,
+			asks:        []string{"a", "b"},
+			expected:    "c",
+			cmd:         "replace",
+		}, {
+			description: "A map with no command.",
+			in:          `{"a":{"b":"c"}}`,
+			asks:        []string{"a"},
+			expected:    map[string]any{"b": "c"},
+			cmd:         "splice",
+		}, {
+			description: "A parent replace wins.",
+			in:          `{"a((replace))":{"b((keep))":"c"}}`,
+			asks:        []string{"a", "b"},
+			expected:    "c",
+			cmd:         "replace",
+		}, {
+			description: "An array with no command.",
+			in:          `{"a":["b","c"]}`,
+			asks:        []string{"a"},
+			expected:    []any{"b", "c"},
+			cmd:         "append",
+		}, {
+			description: "An array element.",
+			in:          `{"a((prepend))":["b","c"]}`,
+			asks:        []string{"a", "1"},
+			expected:    "c",
+			cmd:         "prepend",
+		}, {
+			description: "An array element with a union command.",
+			in:          `{"a((union))":["b","c"]}`,
+			asks:        []string{"a", "0"},
+			expected:    "b",
+			cmd:         "union",
+		}, {
+			description: "An array element with a default command.",
+			in:          `{"a":["b","c"]}`,
+			asks:        []string{"a", "0"},
+			expected:    "b",
+			cmd:         "append",
+		}, {
+			description: "An invalid array index.",
+			in:          `{"a":["b","c"]}`,
+			asks:        []string{"a", "x"},
+			cmd:         "append",
+			notFound:    true,
+		}, {
+			description: "A missing key.",
+			in:          `{"a":"b"}`,
+			asks:        []string{"c"},
+			notFound:    true,
+		}, {
+			description: "A missing key below a value.",
+			in:          `{"a":"b"}`,
+			asks:        []string{"a", "b"},
+			notFound:    true,
+		}, {
+			description: "A deleted key.",
+			in:          `{"a((delete))":null}`,
+			asks:        []string{"a"},
+			cmd:         "delete",
+		}, {
+			description: "A key below a deleted key.",
+			in:          `{"a((delete))":null}`,
+			asks:        []string{"a", "b"},
+			cmd:         "delete",
+			notFound:    true,
+		}, {
+			description: "A missing key with clear.",
+			in:          `{"x((clear))":"b"}`,
+			asks:        []string{"c"},
+			cmd:         "clear",
+			notFound:    true,
+		}, {
+			description: "A present key with clear.",
+			in:          `{"x((clear))":"b", "c":"d"}`,
+			asks:        []string{"c"},
+			expected:    "d",
+			cmd:         "clear",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, cmd, found := decode(tc.in).Lookup(tc.asks)
+
+			assert.Equal(tc.cmd, cmd)
+			assert.Equal(!tc.notFound, found)
+			if !tc.notFound && tc.expected != nil {
+				assert.Equal(tc.expected, got.ToRaw())
+			}
+		})
+	}
+}
@@ -14,14 +14,20 @@ import (
 const redactedText = "REDACTED"
 
 const (
-	cmdSecret  = "secret"
-	cmdReplace = "replace"
-	cmdKeep    = "keep"
-	cmdFail    = "fail"
-	cmdAppend  = "append"
-	cmdPrepend = "prepend"
-	cmdSplice  = "splice"
-	cmdClear   = "clear"
+	cmdSecret     = "secret"
+	cmdReplace    = "replace"
+	cmdKeep       = "keep"
+	cmdFail       = "fail"
+	cmdAppend     = "append"
+	cmdPrepend    = "prepend"
+	cmdUnion      = "union"
+	cmdMergeBy    = "merge-by"
+	cmdSplice     = "splice"
+	cmdClear      = "clear"
+	cmdDelete     = "delete"
+	cmdFinal      = "final"
+	cmdDeprecated = "deprecated"
+	cmdEncrypted  = "encrypted"
 )
 
 var (
@@ -32,6 +38,7 @@ var (
 	ErrInvalidIndex     = errors.New("invalid index")
 	ErrRecursionTooDeep = errors.New("recursion too deep")
 	ErrNonSerializable  = errors.New("non-serializeable objects encountered")
+	ErrFinal            = errors.New("a final value can not be changed")
 )
 
 // Origin provides details about an origin of a parameter.
@@ -74,11 +81,14 @@ const (
 // So for example, if you add to the Array field and the Value field, the Value
 // field will always be ignored.
 type Object struct {
-	Origins []Origin          // The list of origins that influenced this Object.
-	Array   []Object          // The array of Objects (if a map).
-	Map     map[string]Object // The map of Objects (if a map).
-	Value   any               // The value of the configuration parameter (if a value).
-	secret  bool              // If the value is secret.
+	Origins    []Origin          // The list of origins that influenced this Object.
+	Array      []Object          // The array of Objects (if a map).
+	Map        map[string]Object // The map of Objects (if a map).
+	Value      any               // The value of the configuration parameter (if a value).
+	secret     bool              // If the value is secret.
+	locked     bool              // If the value is final and can not be changed.
+	deprecated bool              // If the key of the value is deprecated.
+	encrypted  bool              // If the value needs to be decrypted.
 }
 
 // Kind provides the specific kind of Object this is.  Array, Map or Value.  If
@@ -174,6 +184,37 @@ func (obj Object) ToRaw() any {
 	return obj.Value
 }
 
+// IsSecret returns true if the Object was marked as secret.
+func (obj Object) IsSecret() bool {
+	return obj.secret
+}
+
+// ToRawWithSecrets converts an Object tree into a native go tree like ToRaw,
+// except the native form of each secret Object is passed to the secret function
+// and the result is used in its place.
+func (obj Object) ToRawWithSecrets(secret func(any) any) any {
+	if obj.secret {
+		return secret(obj.ToRaw())
+	}
+
+	switch obj.Kind() {
+	case Array:
+		rv := make([]any, len(obj.Array))
+		for i, val := range obj.Array {
+			rv[i] = val.ToRawWithSecrets(secret)
+		}
+		return rv
+	case Map:
+		rv := make(map[string]any)
+
+		for key, val := range obj.Map {
+			rv[key] = val.ToRawWithSecrets(secret)
+		}
+		return rv
+	}
+	return obj.Value
+}
+
 // ObjectFromRaw converts a native go tree into the equivalent Object tree structure.
 func ObjectFromRaw(in any, at ...string) (obj Object) {
 	return ObjectFromRawWithOrigin(in, nil, at...)
@@ -311,6 +352,41 @@ func StringToBestType(s string) any {
 	return s
 }
 
+// ToSecret builds a copy of the tree where the Objects with a path (map keys and
+// array indexes) that matches are marked as secret.  The Objects below a secret
+// Object are not checked.
+func (obj Object) ToSecret(match func(path []string) bool) Object {
+	return obj.toSecret(nil, match)
+}
+
+func (obj Object) toSecret(path []string, match func([]string) bool) Object {
+	if obj.secret {
+		return obj
+	}
+
+	if len(path) > 0 && match(append([]string{}, path...)) {
+		obj.secret = true
+		return obj
+	}
+
+	switch obj.Kind() {
+	case Array:
+		array := make([]Object, len(obj.Array))
+		for i, val := range obj.Array {
+			array[i] = val.toSecret(append(path, strconv.Itoa(i)), match)
+		}
+		obj.Array = array
+	case Map:
+		m := make(map[string]Object, len(obj.Map))
+		for key, val := range obj.Map {
+			m[key] = val.toSecret(append(path, key), match)
+		}
+		obj.Map = m
+	}
+
+	return obj
+}
+
 // ToRedacted builds a copy of the tree where secrets are redacted.  Secret maps
 // or arrays will now show up as values containing the value 'REDACTED'.
 func (obj Object) ToRedacted() Object {
@@ -505,9 +581,16 @@ func (obj Object) AlterKeyCase(to func(string) string) Object {
 		m := make(map[string]Object)
 
 		for key, val := range obj.Map {
-			target := to(key)
+			// Only the name is altered, the commands are left as they are.
+			name, cmds := key, ""
+			if sub := outerRe.FindStringSubmatch(key); len(sub) != 0 {
+				name = strings.TrimSpace(sub[1])
+				cmds = key[len(sub[1]):]
+			}
+
+			target := to(name)
 			if target != "-" {
-				m[target] = val.AlterKeyCase(to)
+				m[target+cmds] = val.AlterKeyCase(to)
 			}
 		}
 		obj.Map = m
@@ -547,11 +630,15 @@ func (obj Object) resolveCommands(secret bool) (Object, error) {
 			if err != nil {
 				return Object{}, err
 			}
+			if cmd.cmd == cmdDelete || val.onlyDeletes() {
+				// There is nothing to delete, so leave the key out.
+				continue
+			}
 			tmp, err := val.resolveCommands(cmd.secret)
 			if err != nil {
 				return Object{}, err
 			}
-			m[cmd.final] = tmp
+			m[cmd.final] = tmp.mark(cmd)
 		}
 		obj.Map = m
 	}
@@ -559,9 +646,39 @@ func (obj Object) resolveCommands(secret bool) (Object, error) {
 	return obj, nil
 }
 
+// onlyDeletes returns true if the Object is a map that only holds 'delete'
+// commands, directly or in the maps below it.  Such a map has nothing to add
+// to a tree that doesn't already hold it.
+func (obj Object) onlyDeletes() bool {
+	if obj.Kind() != Map || len(obj.Map) == 0 {
+		return false
+	}
+
+	for key, val := range obj.Map {
+		cmd, err := getCmd(key)
+		if err != nil {
+			return false
+		}
+		if cmd.cmd != cmdDelete && !val.onlyDeletes() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Merge performs a merge of the new Object tree onto the existing Object tree
 // using the default semantics and merge rules found in the key commands.
 func (obj Object) Merge(next Object) (Object, error) {
+	return obj.MergeWithStrategy(next, nil, ".")
+}
+
+// MergeWithStrategy performs a merge of the new Object tree onto the existing
+// Object tree using the merge rules found in the key commands.  When a key
+// doesn't have a merge command, the Strategy is consulted for the command to
+// use before falling back to the default semantics.  The keyDelimiter is used to
+// join the keys in any errors.
+func (obj Object) MergeWithStrategy(next Object, s Strategy, keyDelimiter string) (Object, error) {
 	// The 'clear' command is special in that if it is found at all, it
 	// overwrites everything else in the existing tree and exists the merge.
 	for k := range next.Map {
@@ -570,22 +687,45 @@ func (obj Object) Merge(next Object) (Object, error) {
 			return Object{}, err
 		}
 		if cmd.cmd == cmdClear {
-			return Object{Origins: []Origin{}}, nil
+			return enforceFinal(obj.finals(nil), Object{Origins: []Origin{}}, keyDelimiter, next.changedBy)
 		}
 	}
 
-	return obj.merge(command{}, next)
+	// The merge alters the existing tree, so gather the final and deprecated
+	// Objects first.
+	finals := obj.finals(nil)
+	deprecated := obj.deprecatedPaths(nil)
+
+	rv, err := obj.merge(merger{strategy: s, delimiter: keyDelimiter}, command{}, next)
+	if err != nil {
+		return Object{}, err
+	}
+
+	rv, err = enforceFinal(finals, rv, keyDelimiter, next.changedBy)
+	if err != nil {
+		return Object{}, err
+	}
+
+	return markDeprecated(deprecated, rv), nil
 }
 
 // merge does the actual merging of the trees.
-func (obj Object) merge(cmd command, next Object) (Object, error) {
+func (obj Object) merge(m merger, cmd command, next Object) (Object, error) {
+	if obj.Kind() == next.Kind() {
+		var err error
+		cmd, err = m.withStrategy(cmd, next)
+		if err != nil {
+			return Object{}, err
+		}
+	}
+
 	switch obj.Kind() {
 	case Value:
 		return obj.mergeValue(cmd, next)
 	case Array:
 		return obj.mergeArray(cmd, next)
 	}
-	return obj.mergeMap(cmd, next)
+	return obj.mergeMap(m, cmd, next)
 }
 
 // mergeValue merges two values.  Don't directly call this, call merge() instead.
@@ -609,6 +749,10 @@ func (obj Object) mergeValue(cmd command, next Object) (Object, error) {
 
 // mergeArray merges two array.  Don't directly call this, call merge() instead.
 func (obj Object) mergeArray(cmd command, next Object) (Object, error) {
+	if cmd.cmd == cmdMergeBy {
+		return obj.mergeArrayBy(cmd, next)
+	}
+
 	rv := obj
 	next, err := next.resolveCommands(obj.secret)
 	if err != nil {
@@ -627,6 +771,17 @@ func (obj Object) mergeArray(cmd command, next Object) (Object, error) {
 		}
 		rv.Origins = append(next.Origins, obj.Origins...)
 		rv.Array = append(next.Array, obj.Array...)
+	case cmdUnion:
+		if obj.secret || next.secret || cmd.secret {
+			rv.secret = true
+		}
+		rv.Origins = append(obj.Origins, next.Origins...)
+		rv.Array = obj.Array
+		for _, item := range next.Array {
+			if !containsValue(rv.Array, item) {
+				rv.Array = append(rv.Array, item)
+			}
+		}
 	case cmdReplace:
 		rv.secret = cmd.secret
 		rv = next
@@ -637,8 +792,76 @@ func (obj Object) mergeArray(cmd command, next Object) (Object, error) {
 	return rv, nil
 }
 
+// mergeArrayBy merges two arrays by matching the map elements using the
+// identity field named by the command.  Matching maps are spliced together and
+// all other elements are appended.  Don't directly call this, call merge()
+// instead.
+func (obj Object) mergeArrayBy(cmd command, next Object) (Object, error) {
+	rv := obj
+	if obj.secret || cmd.secret {
+		rv.secret = true
+	}
+	rv.Origins = append(obj.Origins, next.Origins...)
+	rv.Array = append([]Object{}, obj.Array...)
+
+	for _, item := range next.Array {
+		resolved, err := item.resolveCommands(obj.secret)
+		if err != nil {
+			return Object{}, err
+		}
+
+		i := indexByField(rv.Array, cmd.arg, resolved)
+		if i < 0 {
+			rv.Array = append(rv.Array, resolved)
+			continue
+		}
+
+		// Strategies are based on the path, so they don't apply inside arrays.
+		v, err := rv.Array[i].merge(merger{}, command{}, item)
+		if err != nil {
+			return Object{}, err
+		}
+		rv.Array[i] = v
+	}
+
+	return rv, nil
+}
+
+// indexByField returns the index of the first map in the list with the same
+// value for the field as the item, or -1 if there isn't a match or the item
+// doesn't have the field.
+func indexByField(list []Object, field string, item Object) int {
+	id, found := item.Map[field]
+	if !found || item.Kind() != Map {
+		return -1
+	}
+
+	want := id.ToRaw()
+	for i, v := range list {
+		if v.Kind() != Map {
+			continue
+		}
+		if got, found := v.Map[field]; found && reflect.DeepEqual(want, got.ToRaw()) {
+			return i
+		}
+	}
+	return -1
+}
+
+// containsValue returns true if the list contains an Object with the same value
+// as the item.  The origins of the Objects are ignored.
+func containsValue(list []Object, item Object) bool {
+	want := item.ToRaw()
+	for _, v := range list {
+		if reflect.DeepEqual(want, v.ToRaw()) {
+			return true
+		}
+	}
+	return false
+}
+
 // mergeMap merges two maps.  Don't directly call this, call merge() instead.
-func (obj Object) mergeMap(cmd command, next Object) (Object, error) {
+func (obj Object) mergeMap(m merger, cmd command, next Object) (Object, error) {
 	switch cmd.cmd {
 	case cmdFail:
 		return Object{}, fmt.Errorf("%w: merging a map with command 'fail'", ErrConflict)
@@ -661,23 +884,33 @@ func (obj Object) mergeMap(cmd command, next Object) (Object, error) {
 			return Object{}, err
 		}
 
+		if newCmd.cmd == cmdDelete {
+			delete(obj.Map, newCmd.final)
+			continue
+		}
+
 		existing, found := obj.Map[newCmd.final]
 		if !found {
+			if val.onlyDeletes() {
+				// A delete never creates the parents of what it deletes.
+				continue
+			}
+
 			// Merging with no conflicts.
 			v, err := val.resolveCommands(newCmd.secret)
 			if err != nil {
 				return Object{}, err
 			}
-			obj.Map[newCmd.final] = v
+			obj.Map[newCmd.final] = v.mark(newCmd)
 			continue
 		}
 
 		if existing.Kind() == val.Kind() {
-			v, err := existing.merge(newCmd, val)
+			v, err := existing.merge(m.at(newCmd.final), newCmd, val)
 			if err != nil {
 				return Object{}, err
 			}
-			obj.Map[newCmd.final] = v
+			obj.Map[newCmd.final] = v.mark(newCmd)
 			continue
 		}
 
@@ -687,7 +920,7 @@ func (obj Object) mergeMap(cmd command, next Object) (Object, error) {
 			if err != nil {
 				return Object{}, err
 			}
-			obj.Map[newCmd.final] = v
+			obj.Map[newCmd.final] = v.mark(newCmd)
 		case cmdKeep:
 			obj.Map[newCmd.final] = existing
 		case cmdFail:
@@ -699,6 +932,23 @@ func (obj Object) mergeMap(cmd command, next Object) (Object, error) {
 	return obj, nil
 }
 
+// mark marks the Object as final, deprecated or encrypted if the command
+// includes 'final', 'deprecated' or 'encrypted'.  Encrypted Objects are also
+// marked as secret.
+func (obj Object) mark(cmd command) Object {
+	if cmd.locked {
+		obj.locked = true
+	}
+	if cmd.deprecated {
+		obj.deprecated = true
+	}
+	if cmd.encrypted {
+		obj.encrypted = true
+		obj.secret = true
+	}
+	return obj
+}
+
 // getValidCmd gets the command from the key string and validates it is supported.
 func getValidCmd(key string, obj Object) (command, error) {
 	cmd, err := getCmd(key)
@@ -706,22 +956,33 @@ func getValidCmd(key string, obj Object) (command, error) {
 		return command{}, err
 	}
 
+	// Only values can be encrypted.
+	if cmd.encrypted && obj.Kind() != Value {
+		return command{}, ErrInvalidCommand
+	}
+
+	if validCmd(obj.Kind(), cmd.cmd) {
+		return cmd, nil
+	}
+
+	return command{}, ErrInvalidCommand
+}
+
+// validCmd returns if the command is supported for the kind of Object.
+func validCmd(kind int, cmd string) bool {
 	list := map[int][]string{
-		Map:   {"", cmdFail, cmdKeep, cmdReplace, cmdSplice},
-		Array: {"", cmdFail, cmdKeep, cmdReplace, cmdAppend, cmdPrepend},
-		Value: {"", cmdFail, cmdKeep, cmdReplace},
+		Map:   {"", cmdFail, cmdKeep, cmdReplace, cmdDelete, cmdSplice},
+		Array: {"", cmdFail, cmdKeep, cmdReplace, cmdDelete, cmdAppend, cmdPrepend, cmdUnion, cmdMergeBy},
+		Value: {"", cmdFail, cmdKeep, cmdReplace, cmdDelete},
 	}
 
-	opts, found := list[obj.Kind()]
-	if found {
-		for _, opt := range opts {
-			if cmd.cmd == opt {
-				return cmd, nil
-			}
+	for _, opt := range list[kind] {
+		if cmd == opt {
+			return true
 		}
 	}
 
-	return command{}, ErrInvalidCommand
+	return false
 }
 
 // FilterNonSerializable builds a copy of the tree where any non-serializeable
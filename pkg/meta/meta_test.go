@@ -751,6 +751,65 @@ func TestStringToBestType(t *testing.T) {
 	}
 }
 
+func TestToRawWithSecrets(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	in, err := decode(`{
+		"a((secret))": "b",
+		"c": {"d((secret))": {"e": "f"}, "g": ["h"]},
+		"i((secret))": ["j"]
+	}`).ResolveCommands()
+	require.NoError(err)
+
+	assert.True(in.Map["a"].IsSecret())
+	assert.False(in.Map["c"].IsSecret())
+
+	got := in.ToRawWithSecrets(func(v any) any {
+		return []any{"secret", v}
+	})
+	assert.Equal(map[string]any{
+		"a": []any{"secret", "b"},
+		"c": map[string]any{
+			"d": []any{"secret", map[string]any{"e": "f"}},
+			"g": []any{"h"},
+		},
+		"i": []any{"secret", []any{"j"}},
+	}, got)
+}
+
+func TestToSecret(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	in, err := decode(`{
+		"a": "b",
+		"c": {"password": "d", "e": ["f", "g"]},
+		"h((secret))": {"password": "i"}
+	}`).ResolveCommands()
+	require.NoError(err)
+
+	var paths []string
+	got := in.ToSecret(func(path []string) bool {
+		p := strings.Join(path, ".")
+		paths = append(paths, p)
+		return p == "c.password" || p == "c.e.1"
+	})
+
+	assert.True(got.Map["c"].Map["password"].IsSecret())
+	assert.True(got.Map["c"].Map["e"].Array[1].IsSecret())
+	assert.False(got.Map["c"].Map["e"].Array[0].IsSecret())
+	assert.False(got.Map["c"].IsSecret())
+	assert.False(got.Map["a"].IsSecret())
+	assert.True(got.Map["h"].IsSecret())
+
+	// The tree is not altered in place & secrets are not traversed.
+	assert.False(in.Map["c"].Map["password"].IsSecret())
+	assert.NotContains(paths, "h")
+	assert.NotContains(paths, "h.password")
+	assert.Contains(paths, "c.e.0")
+}
+
 func TestToRedacted(t *testing.T) {
 	tests := []struct {
 		description string
@@ -1133,6 +1192,16 @@ func TestAlterKeyCase(t *testing.T) {
 				return strings.ToLower(s)
 			},
 			expected: `{"foo":{ "bar": "oNe" } }`,
+		}, {
+			description: "Output a tree with commands in the keys.",
+			in:          `{"FOO((secret))":{ "BAR ((replace))": "oNe" }, "Car((delete))": null }`,
+			mapper: func(s string) string {
+				if s == "Car" {
+					return "-"
+				}
+				return strings.ToLower(s)
+			},
+			expected: `{"foo((secret))":{ "bar ((replace))": "oNe" } }`,
 		},
 	}
 	for _, tc := range tests {
@@ -1475,6 +1544,230 @@ func TestMerge(t *testing.T) {
 					},
 				},
 			},
+		}, {
+			description: "Union with an array.",
+			in:          `{"foo":["bar", {"a":"b"}, "car"]}`,
+			next:        `{"foo((union))":["car", {"a":"b"}, "dog", {"a":"c"}, "dog"]}`,
+			expected: Object{
+				Origins: []Origin{},
+				Map: map[string]Object{
+					"foo": {
+						Origins: []Origin{},
+						Array: []Object{
+							{
+								Origins: []Origin{},
+								Value:   "bar",
+							}, {
+								Origins: []Origin{},
+								Map: map[string]Object{
+									"a": {
+										Origins: []Origin{},
+										Value:   "b",
+									},
+								},
+							}, {
+								Origins: []Origin{},
+								Value:   "car",
+							}, {
+								Origins: []Origin{},
+								Value:   "dog",
+							}, {
+								Origins: []Origin{},
+								Map: map[string]Object{
+									"a": {
+										Origins: []Origin{},
+										Value:   "c",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Union with a secret node and make sure it stays secret.",
+			in:          `{"foo((secret))":["bar"]}`,
+			next:        `{"foo((union))":["bar", "car"]}`,
+			expected: Object{
+				Origins: []Origin{},
+				Map: map[string]Object{
+					"foo": {
+						Origins: []Origin{},
+						secret:  true,
+						Array: []Object{
+							{
+								Origins: []Origin{},
+								Value:   "bar",
+							}, {
+								Origins: []Origin{},
+								Value:   "car",
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Union with a secret command.",
+			in:          `{"foo":["bar"]}`,
+			next:        `{"foo((union, secret))":["car"]}`,
+			expected: Object{
+				Origins: []Origin{},
+				Map: map[string]Object{
+					"foo": {
+						Origins: []Origin{},
+						secret:  true,
+						Array: []Object{
+							{
+								Origins: []Origin{},
+								Value:   "bar",
+							}, {
+								Origins: []Origin{},
+								Value:   "car",
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Merge an array of maps by a field.",
+			in:          `{"foo":[{"name":"a", "port":1}, {"name":"b", "port":2}, "c"]}`,
+			next:        `{"foo((merge-by name))":[{"name":"b", "port":3, "tls":true}, {"name":"d"}, "c", {"port":4}]}`,
+			expected: Object{
+				Origins: []Origin{},
+				Map: map[string]Object{
+					"foo": {
+						Origins: []Origin{},
+						Array: []Object{
+							{
+								Origins: []Origin{},
+								Map: map[string]Object{
+									"name": {Origins: []Origin{}, Value: "a"},
+									"port": {Origins: []Origin{}, Value: float64(1)},
+								},
+							}, {
+								Origins: []Origin{},
+								Map: map[string]Object{
+									"name": {Origins: []Origin{}, Value: "b"},
+									"port": {Origins: []Origin{}, Value: float64(3)},
+									"tls":  {Origins: []Origin{}, Value: true},
+								},
+							}, {
+								Origins: []Origin{},
+								Value:   "c",
+							}, {
+								Origins: []Origin{},
+								Map: map[string]Object{
+									"name": {Origins: []Origin{}, Value: "d"},
+								},
+							}, {
+								Origins: []Origin{},
+								Value:   "c",
+							}, {
+								Origins: []Origin{},
+								Map: map[string]Object{
+									"port": {Origins: []Origin{}, Value: float64(4)},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Merge an array of maps by a field with commands in the elements.",
+			in:          `{"foo":[{"name":"a", "port":1, "host":"x"}]}`,
+			next:        `{"foo((merge-by name, secret))":[{"name":"a", "port((keep))":3, "host((secret))":"y"}]}`,
+			expected: Object{
+				Origins: []Origin{},
+				Map: map[string]Object{
+					"foo": {
+						Origins: []Origin{},
+						secret:  true,
+						Array: []Object{
+							{
+								Origins: []Origin{},
+								Map: map[string]Object{
+									"name": {Origins: []Origin{}, Value: "a"},
+									"port": {Origins: []Origin{}, Value: float64(1)},
+									"host": {Origins: []Origin{}, Value: "y", secret: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Error merge by with a conflict in an element.",
+			in:          `{"foo":[{"name":"a", "port":1}]}`,
+			next:        `{"foo((merge-by name))":[{"name":"a", "port((fail))":3}]}`,
+			expectedErr: ErrConflict,
+		}, {
+			description: "Error merge by with an invalid command in an element.",
+			in:          `{"foo":[{"name":"a", "port":1}]}`,
+			next:        `{"foo((merge-by name))":[{"name":"b", "port((invalid))":3}]}`,
+			expectedErr: ErrInvalidCommand,
+		}, {
+			description: "Error merge by with a map.",
+			in:          `{"foo":{"name":"a"}}`,
+			next:        `{"foo((merge-by name))":{"name":"b"}}`,
+			expectedErr: ErrInvalidCommand,
+		}, {
+			description: "Delete keys of each kind.",
+			in:          `{"foo":{"a":"b"}, "bar":["car"], "goo":"dog", "keep":"me"}`,
+			next:        `{"foo((delete))":null, "bar((delete))":["x"], "goo((delete))":{"y":"z"}, "missing((delete))":null}`,
+			expected: Object{
+				Origins: []Origin{},
+				Map: map[string]Object{
+					"keep": {
+						Origins: []Origin{},
+						Value:   "me",
+					},
+				},
+			},
+		}, {
+			description: "Delete a nested key.",
+			in:          `{"foo":{"a":"b", "c":"d"}}`,
+			next:        `{"foo":{"a((delete))":null}, "new":{"x((delete))":null}}`,
+			expected: Object{
+				Origins: []Origin{},
+				Map: map[string]Object{
+					"foo": {
+						Origins: []Origin{},
+						Map: map[string]Object{
+							"c": {
+								Origins: []Origin{},
+								Value:   "d",
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Delete a key below a missing parent.",
+			in:          `{"keep":"me"}`,
+			next:        `{"nope":{"child((splice))":{"leaf((delete))":null}}, "new":{"x":{"y((delete))":null}, "z":"a"}}`,
+			expected: Object{
+				Origins: []Origin{},
+				Map: map[string]Object{
+					"keep": {
+						Origins: []Origin{},
+						Value:   "me",
+					},
+					"new": {
+						Origins: []Origin{},
+						Map: map[string]Object{
+							"z": {
+								Origins: []Origin{},
+								Value:   "a",
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Error union with a value.",
+			in:          `{"foo":"bar"}`,
+			next:        `{"foo((union))":"new"}`,
+			expectedErr: ErrInvalidCommand,
 		}, {
 			description: "Error attempting to clear.",
 			in:          `{"foo":"bar"}`,
@@ -1559,6 +1852,32 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestMergeUnionOrigins(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	first := Origin{File: "first.json"}
+	second := Origin{File: "second.json"}
+
+	in, err := ObjectFromRawWithOrigin(map[string]any{
+		"foo": []any{"bar"},
+	}, []Origin{first}).resolveCommands(false)
+	require.NoError(err)
+
+	next := ObjectFromRawWithOrigin(map[string]any{
+		"foo((union))": []any{"bar", "car"},
+	}, []Origin{second})
+
+	got, err := in.Merge(next)
+	require.NoError(err)
+
+	foo := got.Map["foo"]
+	require.Equal(2, len(foo.Array))
+	assert.Equal([]Origin{first}, foo.Array[0].Origins)
+	assert.Equal([]Origin{second}, foo.Array[1].Origins)
+	assert.Equal([]Origin{first, second}, foo.Origins)
+}
+
 func TestOrigin_OriginString(t *testing.T) {
 	tests := []struct {
 		description string
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Strategy provides the merge command to use for the Object at the path when
+// the key doesn't include a merge command.  The kind is the kind of the Object
+// being merged (Array, Map or Value).  The command is in the same form as found
+// inside the (( )) of a key, for example "replace" or "merge-by name".  If ""
+// is returned, the default semantics are used.
+//
+// Strategies are based on the path of map keys, so they don't apply to the
+// Objects inside arrays.
+type Strategy func(path []string, kind int) string
+
+// ValidStrategy returns an error if the command can not be returned by a
+// Strategy for the kind of Object.  The 'secret', 'final', 'deprecated',
+// 'encrypted', 'delete' and 'clear' commands are never valid strategies.
+func ValidStrategy(kind int, s string) error {
+	cmd, err := parseCmds(s)
+	if err != nil {
+		return err
+	}
+
+	if cmd.secret || cmd.locked || cmd.deprecated || cmd.encrypted ||
+		cmd.cmd == cmdDelete || !validCmd(kind, cmd.cmd) {
+		return fmt.Errorf("%w: '%s' is not a valid strategy", ErrInvalidCommand, s)
+	}
+
+	return nil
+}
+
+// merger holds the state needed while merging trees.
+type merger struct {
+	strategy  Strategy
+	delimiter string
+	path      []string
+}
+
+// at returns the merger for the key below the present path.
+func (m merger) at(key string) merger {
+	if m.strategy == nil {
+		return m
+	}
+
+	path := make([]string, len(m.path), len(m.path)+1)
+	copy(path, m.path)
+
+	return merger{
+		strategy:  m.strategy,
+		delimiter: m.delimiter,
+		path:      append(path, key),
+	}
+}
+
+// withStrategy fills in the command from the strategy if the key didn't
+// provide one.
+func (m merger) withStrategy(cmd command, next Object) (command, error) {
+	s := strategyFor(m.strategy, m.path, cmd, next)
+	if s == "" {
+		return cmd, nil
+	}
+
+	if err := ValidStrategy(next.Kind(), s); err != nil {
+		return command{}, fmt.Errorf("%w at '%s'", err, strings.Join(m.path, m.delimiter))
+	}
+
+	parsed, _ := parseCmds(s)
+	cmd.cmd = parsed.cmd
+	cmd.arg = parsed.arg
+
+	return cmd, nil
+}
+
+// strategyFor returns the strategy that applies if the command doesn't have
+// a merge command of its own.
+func strategyFor(s Strategy, path []string, cmd command, next Object) string {
+	if s == nil || cmd.cmd != "" {
+		return ""
+	}
+
+	return s(path, next.Kind())
+}
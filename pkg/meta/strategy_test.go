@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeWithStrategy(t *testing.T) {
+	strategies := map[string]string{
+		"replaced": "replace",
+		"kept":     "keep",
+		"a.b":      "prepend",
+		"servers":  "merge-by name",
+		"invalid":  "splice",
+		"a.bad":    "bad command",
+		"bad":      "bad command",
+		"deleted":  "delete",
+	}
+	strategy := func(path []string, kind int) string {
+		if s, found := strategies[strings.Join(path, ".")]; found {
+			return s
+		}
+		if kind == Array {
+			return "replace"
+		}
+		return ""
+	}
+
+	tests := []struct {
+		description string
+		in          string
+		next        string
+		expected    any
+		expectedErr error
+		errText     string
+	}{
+		{
+			description: "The default array strategy.",
+			in:          `{"list":["a"], "other":"x"}`,
+			next:        `{"list":["b"], "other":"y"}`,
+			expected:    map[string]any{"list": []any{"b"}, "other": "y"},
+		}, {
+			description: "An explicit command wins.",
+			in:          `{"list":["a"]}`,
+			next:        `{"list((append))":["b"]}`,
+			expected:    map[string]any{"list": []any{"a", "b"}},
+		}, {
+			description: "A secret without a command still uses the strategy.",
+			in:          `{"list":["a"]}`,
+			next:        `{"list((secret))":["b"]}`,
+			expected:    map[string]any{"list": []any{"b"}},
+		}, {
+			description: "Strategies at paths.",
+			in:          `{"replaced":{"a":"b"}, "kept":"x", "a":{"b":["c"]}}`,
+			next:        `{"replaced":{"c":"d"}, "kept":"y", "a":{"b":["d"]}}`,
+			expected: map[string]any{
+				"replaced": map[string]any{"c": "d"},
+				"kept":     "x",
+				"a":        map[string]any{"b": []any{"d", "c"}},
+			},
+		}, {
+			description: "A strategy with an argument.",
+			in:          `{"servers":[{"name":"a", "port":1}]}`,
+			next:        `{"servers":[{"name":"a", "port":2}, {"name":"b"}]}`,
+			expected: map[string]any{
+				"servers": []any{
+					map[string]any{"name": "a", "port": float64(2)},
+					map[string]any{"name": "b"},
+				},
+			},
+		}, {
+			description: "A strategy doesn't apply when the kinds differ.",
+			in:          `{"kept":{"a":"b"}}`,
+			next:        `{"kept":"y"}`,
+			expected:    map[string]any{"kept": "y"},
+		}, {
+			description: "An invalid strategy for the kind.",
+			in:          `{"invalid":["a"]}`,
+			next:        `{"invalid":["b"]}`,
+			expectedErr: ErrInvalidCommand,
+		}, {
+			description: "An invalid strategy.",
+			in:          `{"bad":"a"}`,
+			next:        `{"bad":"b"}`,
+			expectedErr: ErrInvalidCommand,
+		}, {
+			description: "An invalid strategy below a map.",
+			in:          `{"a":{"bad":"a"}}`,
+			next:        `{"a":{"bad":"b"}}`,
+			expectedErr: ErrInvalidCommand,
+			errText:     "at 'a/bad'",
+		}, {
+			description: "The delete command is not a valid strategy.",
+			in:          `{"deleted":"a"}`,
+			next:        `{"deleted":"b"}`,
+			expectedErr: ErrInvalidCommand,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			in, err := decode(tc.in).ResolveCommands()
+			require.NoError(err)
+
+			got, err := in.MergeWithStrategy(decode(tc.next), strategy, "/")
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				if tc.errText != "" {
+					assert.ErrorContains(err, tc.errText)
+				}
+				return
+			}
+
+			require.NoError(err)
+			assert.Equal(tc.expected, got.ToRaw())
+		})
+	}
+}
+
+func TestLookupWithStrategy(t *testing.T) {
+	strategy := func(path []string, kind int) string {
+		switch strings.Join(path, ".") {
+		case "a":
+			return "keep"
+		case "b":
+			return "merge-by name"
+		case "c":
+			return "splice"
+		case "r":
+			return "replace"
+		}
+		return ""
+	}
+
+	tests := []struct {
+		description string
+		in          string
+		asks        []string
+		root        bool
+		cmd         string
+	}{
+		{
+			description: "A path.",
+			in:          `{"a":"y"}`,
+			asks:        []string{"a"},
+			cmd:         "keep",
+		}, {
+			description: "A strategy with an argument.",
+			in:          `{"b((secret))":["y"]}`,
+			asks:        []string{"b"},
+			cmd:         "merge-by",
+		}, {
+			description: "An explicit command wins.",
+			in:          `{"a((fail))":"y"}`,
+			asks:        []string{"a"},
+			cmd:         "fail",
+		}, {
+			description: "An invalid strategy is ignored.",
+			in:          `{"c":["y"]}`,
+			asks:        []string{"c"},
+			cmd:         "append",
+		}, {
+			description: "A parent strategy is inherited.",
+			in:          `{"r":{"x":"y"}}`,
+			asks:        []string{"r", "x"},
+			cmd:         "replace",
+		}, {
+			description: "A root strategy.",
+			in:          `{"x":"y"}`,
+			root:        true,
+			cmd:         "replace",
+		}, {
+			description: "A root strategy is inherited.",
+			in:          `{"x":"y"}`,
+			asks:        []string{"x"},
+			root:        true,
+			cmd:         "replace",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			s := strategy
+			if tc.root {
+				s = func(path []string, kind int) string {
+					if len(path) == 0 {
+						return "replace"
+					}
+					return strategy(path, kind)
+				}
+			}
+
+			_, cmd, found := decode(tc.in).LookupWithStrategy(tc.asks, s)
+			assert.True(found)
+			assert.Equal(tc.cmd, cmd)
+		})
+	}
+}
+
+func TestValidStrategy(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(ValidStrategy(Array, "merge-by name"))
+	assert.NoError(ValidStrategy(Map, "splice"))
+	assert.NoError(ValidStrategy(Value, "keep"))
+	assert.ErrorIs(ValidStrategy(Value, "splice"), ErrInvalidCommand)
+	assert.ErrorIs(ValidStrategy(Array, "secret"), ErrInvalidCommand)
+	assert.ErrorIs(ValidStrategy(Array, "delete"), ErrInvalidCommand)
+	assert.ErrorIs(ValidStrategy(Value, "deprecated"), ErrInvalidCommand)
+	assert.ErrorIs(ValidStrategy(Value, "encrypted"), ErrInvalidCommand)
+	assert.ErrorIs(ValidStrategy(Array, "clear"), ErrInvalidCommand)
+	assert.ErrorIs(ValidStrategy(Array, "merge-by"), ErrInvalidCommand)
+}
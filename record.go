@@ -4,6 +4,8 @@
 package goschtalt
 
 import (
+	"context"
+
 	"github.com/goschtalt/goschtalt/pkg/decoder"
 	"github.com/goschtalt/goschtalt/pkg/meta"
 )
@@ -11,16 +13,17 @@ import (
 // record is the basic unit needed to define a configuration and it's name.
 // With this information all the records can be decoded.
 type record struct {
-	name string
-	val  *value
-	buf  *buffer
-	tree meta.Object
+	name  string
+	val   *value
+	buf   *buffer
+	patch *patch
+	tree  meta.Object
 }
 
 // fetch normalizes the calls to the val or encoded types of records.
-func (rec *record) fetch(delimiter string, u Unmarshaler, decoders *codecRegistry[decoder.Decoder], defaultOpts []ValueOption) error {
+func (rec *record) fetch(ctx context.Context, delimiter string, u Unmarshaler, decoders *codecRegistry[decoder.Decoder], defaultOpts []ValueOption) error {
 	if rec.val != nil {
-		tree, err := rec.val.toTree(delimiter, u, defaultOpts...)
+		tree, err := rec.val.toTree(ctx, delimiter, u, defaultOpts...)
 		if err != nil {
 			return err
 		}
@@ -28,7 +31,7 @@ func (rec *record) fetch(delimiter string, u Unmarshaler, decoders *codecRegistr
 	}
 
 	if rec.buf != nil {
-		tree, err := rec.buf.toTree(delimiter, u, decoders)
+		tree, err := rec.buf.toTree(ctx, delimiter, u, decoders)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/goschtalt/goschtalt/internal/mapstructure"
+	"github.com/goschtalt/goschtalt/internal/print"
+)
+
+const redacted = "REDACTED"
+
+// Secret holds a configuration value that must not be leaked.  The value is
+// redacted when the Secret is formatted, marshaled or logged, and is only
+// available by calling [Secret.Reveal].
+//
+// Unmarshal fills in a Secret from any value, secret or not, that can be
+// unmarshaled into the type T.
+//
+// See also: [FailOnPlainSecrets]
+type Secret[T any] struct {
+	value T
+}
+
+// NewSecret returns a Secret holding the value.
+func NewSecret[T any](value T) Secret[T] {
+	return Secret[T]{value: value}
+}
+
+// Reveal returns the secret value.
+func (s Secret[T]) Reveal() T {
+	return s.value
+}
+
+// String always returns the redacted text.
+func (s Secret[T]) String() string {
+	return redacted
+}
+
+// GoString always returns the type with the redacted text.
+func (s Secret[T]) GoString() string {
+	return fmt.Sprintf("goschtalt.Secret[%T]{%s}", s.value, redacted)
+}
+
+// Format makes sure all of the fmt verbs produce the redacted text.
+func (s Secret[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprint(f, s.GoString())
+		return
+	}
+	fmt.Fprint(f, redacted)
+}
+
+// MarshalJSON always marshals the redacted text.
+func (s Secret[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(redacted)
+}
+
+// MarshalText always marshals the redacted text.
+func (s Secret[T]) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// LogValue always logs the redacted text.
+func (s Secret[T]) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+func (s Secret[T]) revealAny() any {
+	return s.value
+}
+
+func (s *Secret[T]) secretType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (s *Secret[T]) setSecret(v reflect.Value) {
+	s.value = v.Interface().(T)
+}
+
+var (
+	_ fmt.Stringer   = Secret[string]{}
+	_ fmt.GoStringer = Secret[string]{}
+	_ fmt.Formatter  = Secret[string]{}
+	_ json.Marshaler = Secret[string]{}
+	_ slog.LogValuer = Secret[string]{}
+	_ secretSetter   = (*Secret[string])(nil)
+	_ revealer       = Secret[string]{}
+)
+
+// secretSetter is implemented by pointers to Secret so the value can be set
+// without knowing the type T.
+type secretSetter interface {
+	secretType() reflect.Type
+	setSecret(reflect.Value)
+}
+
+var secretSetterType = reflect.TypeOf((*secretSetter)(nil)).Elem()
+
+// revealer is implemented by Secret so the value can be checked without knowing
+// the type T.
+type revealer interface {
+	revealAny() any
+}
+
+// secretValue wraps the values in the tree marked as secret while unmarshaling.
+type secretValue struct {
+	value any
+}
+
+func wrapSecret(v any) any {
+	return secretValue{value: v}
+}
+
+// unwrapSecrets returns the data with all the secretValues replaced by the
+// values they hold and if any secretValues were found.
+func unwrapSecrets(data any) (any, bool) {
+	switch data := data.(type) {
+	case secretValue:
+		v, _ := unwrapSecrets(data.value)
+		return v, true
+	case map[string]any:
+		var found bool
+		rv := make(map[string]any, len(data))
+		for k, v := range data {
+			var f bool
+			rv[k], f = unwrapSecrets(v)
+			found = found || f
+		}
+		return rv, found
+	case []any:
+		var found bool
+		rv := make([]any, len(data))
+		for i, v := range data {
+			var f bool
+			rv[i], f = unwrapSecrets(v)
+			found = found || f
+		}
+		return rv, found
+	}
+	return data, false
+}
+
+// secretDecoder provides the decode hook that fills in Secrets and prevents
+// secretValues from leaking into the result.
+type secretDecoder struct {
+	config      mapstructure.DecoderConfig
+	adapt       func(from, to reflect.Value) (any, error)
+	failOnPlain bool
+}
+
+func (s *secretDecoder) hook(from, to reflect.Value) (any, error) {
+	if reflect.PointerTo(to.Type()).Implements(secretSetterType) {
+		return s.toSecret(from.Interface(), to.Type())
+	}
+
+	data, found := from.Interface(), false
+	if _, ok := data.(secretValue); ok || to.Kind() == reflect.Interface {
+		data, found = unwrapSecrets(data)
+	}
+
+	if found {
+		if s.failOnPlain {
+			return nil, fmt.Errorf("%w: a secret can only be unmarshaled into a goschtalt.Secret, not %s",
+				ErrSecretExposed, to.Type())
+		}
+		from = reflect.ValueOf(data)
+	}
+
+	return s.adapt(from, to)
+}
+
+// toSecret decodes the data into a new Secret of the type.
+func (s *secretDecoder) toSecret(data any, typ reflect.Type) (any, error) {
+	rv := reflect.New(typ)
+	setter := rv.Interface().(secretSetter)
+	value := reflect.New(setter.secretType())
+
+	// The values inside a Secret are protected, so they are allowed to be
+	// secret.
+	inner := &secretDecoder{
+		config: s.config,
+		adapt:  s.adapt,
+	}
+
+	cfg := s.config
+	cfg.Result = value.Interface()
+	cfg.Metadata = nil
+	cfg.DecodeHook = inner.hook
+
+	decoder, err := mapstructure.NewDecoder(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if sv, ok := data.(secretValue); ok {
+		data = sv.value
+	}
+	if err := decoder.Decode(data); err != nil {
+		return nil, err
+	}
+
+	setter.setSecret(value.Elem())
+	return rv.Elem().Interface(), nil
+}
+
+// FailOnPlainSecrets specifies that an error wrapping [ErrSecretExposed]
+// should be returned if a value marked as secret is unmarshaled into anything
+// other than a [Secret].
+//
+// The fail bool value is optional & assumed to be `true` if omitted.  The
+// first specified value is used if provided.  A value of `false` disables the
+// option.
+//
+// # Default
+//
+// Values marked as secret may be unmarshaled into any type.
+func FailOnPlainSecrets(fail ...bool) UnmarshalOption {
+	fail = append(fail, true)
+	return failOnPlainSecretsOption(fail[0])
+}
+
+type failOnPlainSecretsOption bool
+
+func (f failOnPlainSecretsOption) unmarshalApply(opts *unmarshalOptions) error {
+	opts.failOnPlainSecrets = bool(f)
+	return nil
+}
+
+func (f failOnPlainSecretsOption) String() string {
+	return print.P("FailOnPlainSecrets", print.BoolSilentTrue(bool(f)), print.SubOpt())
+}
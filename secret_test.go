@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/goschtalt/goschtalt/internal/mapstructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretRedacts(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s := NewSecret("hunter2")
+	assert.Equal("hunter2", s.Reveal())
+	assert.Equal("REDACTED", s.String())
+	assert.Equal("goschtalt.Secret[string]{REDACTED}", s.GoString())
+
+	for _, format := range []string{"%v", "%+v", "%s", "%q", "%x", "%d"} {
+		assert.Equal("REDACTED", fmt.Sprintf(format, s), format)
+	}
+	assert.Equal("goschtalt.Secret[string]{REDACTED}", fmt.Sprintf("%#v", s))
+
+	n := NewSecret(42)
+	assert.Equal("REDACTED", fmt.Sprintf("%d", n))
+	assert.Equal("goschtalt.Secret[int]{REDACTED}", fmt.Sprintf("%#v", n))
+
+	type creds struct {
+		User     string
+		Password Secret[string]
+	}
+	c := creds{User: "bob", Password: s}
+	assert.Equal("{bob REDACTED}", fmt.Sprintf("%v", c))
+
+	b, err := json.Marshal(c)
+	require.NoError(err)
+	assert.JSONEq(`{"User":"bob", "Password":"REDACTED"}`, string(b))
+
+	text, err := s.MarshalText()
+	require.NoError(err)
+	assert.Equal("REDACTED", string(text))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("login", "password", s)
+	assert.Contains(buf.String(), "password=REDACTED")
+	assert.NotContains(buf.String(), "hunter2")
+
+	func() {
+		defer func() {
+			assert.Equal("REDACTED", fmt.Sprint(recover()))
+		}()
+		panic(s)
+	}()
+}
+
+func TestSecretUnmarshal(t *testing.T) {
+	type db struct {
+		User     string
+		Password Secret[string]
+		Port     Secret[int]
+	}
+
+	type config struct {
+		DB     db
+		Tokens Secret[map[string]string]
+		Plain  string
+	}
+
+	tests := []struct {
+		description string
+		key         string
+		want        any
+		opts        []UnmarshalOption
+		expected    any
+		expectedErr error
+	}{
+		{
+			description: "Secrets are unmarshaled into the struct.",
+			want:        &config{},
+			expected: &config{
+				DB: db{
+					User:     "bob",
+					Password: NewSecret("hunter2"),
+					Port:     NewSecret(5432),
+				},
+				Tokens: NewSecret(map[string]string{"a": "b"}),
+				Plain:  "text",
+			},
+		}, {
+			description: "Secrets are only allowed in a Secret.",
+			want:        &config{},
+			opts:        []UnmarshalOption{FailOnPlainSecrets()},
+			expected: &config{
+				DB: db{
+					User:     "bob",
+					Password: NewSecret("hunter2"),
+					Port:     NewSecret(5432),
+				},
+				Tokens: NewSecret(map[string]string{"a": "b"}),
+				Plain:  "text",
+			},
+		}, {
+			description: "A secret unmarshaled into a string.",
+			key:         "DB.Password",
+			want:        new(string),
+			expected:    func() *string { s := "hunter2"; return &s }(),
+		}, {
+			description: "A secret unmarshaled into a map.",
+			key:         "DB",
+			want:        &map[string]any{},
+			expected: &map[string]any{
+				"User":     "bob",
+				"Password": "hunter2",
+				"Port":     json.Number("5432"),
+			},
+		}, {
+			description: "A secret unmarshaled into a string fails.",
+			key:         "DB.Password",
+			want:        new(string),
+			opts:        []UnmarshalOption{FailOnPlainSecrets(true)},
+			expectedErr: ErrSecretExposed,
+		}, {
+			description: "A nested secret unmarshaled into a map fails.",
+			want:        &map[string]any{},
+			opts:        []UnmarshalOption{FailOnPlainSecrets()},
+			expectedErr: ErrSecretExposed,
+		}, {
+			description: "A secret that can't be decoded.",
+			key:         "DB",
+			want:        &struct{ User Secret[int] }{},
+			expectedErr: mapstructure.ErrDecoding,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				AddBuffer("1.json", []byte(`{
+					"DB": {"User":"bob", "Password((secret))":"hunter2", "Port((secret))":5432},
+					"Tokens((secret))": {"a":"b"},
+					"Plain": "text"
+				}`)),
+				AutoCompile(),
+			)
+			require.NoError(err)
+
+			err = cfg.Unmarshal(tc.key, tc.want, tc.opts...)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(err)
+			assert.Equal(tc.expected, tc.want)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// SecretKeys marks the values with keys matching any of the patterns as secret
+// as each record is merged, the same as if the keys had the ((secret)) command.
+// This protects values like passwords even if the configuration author forgot
+// to use the command.
+//
+// The patterns are matched against the full key path joined by the key
+// delimiter, ignoring case.  Array elements are matched using their index.  In
+// a pattern, '*' matches any number of characters (including the key
+// delimiter) and '?' matches exactly one character.  All other characters
+// match themselves.  Examples (with the default '.' delimiter):
+//
+//   - "*.password" matches "db.password" and "a.b.password", but not "password"
+//   - "*token*" matches "token", "auth.token" and "tokens.refresh"
+//   - "db.?ey" matches "db.key" and "db.Key"
+//
+// SecretKeys may be specified multiple times and the patterns are combined.
+//
+// See also: [RedactSecrets], [FailOnPlainSecrets]
+func SecretKeys(patterns ...string) Option {
+	return &secretKeysOption{
+		patterns: patterns,
+	}
+}
+
+type secretKeysOption struct {
+	patterns []string
+}
+
+func (s secretKeysOption) apply(opts *options) error {
+	for _, pattern := range s.patterns {
+		if pattern == "" {
+			return fmt.Errorf("%w: SecretKeys patterns can not be empty", ErrInvalidInput)
+		}
+		opts.secretKeys = append(opts.secretKeys, globToRegexp(pattern))
+	}
+	return nil
+}
+
+func (secretKeysOption) ignoreDefaults() bool { return false }
+func (s secretKeysOption) String() string {
+	return print.P("SecretKeys", print.Strings(s.patterns))
+}
+
+// globToRegexp converts the glob pattern into a case insensitive regular
+// expression that must match the entire key.
+func globToRegexp(pattern string) *regexp.Regexp {
+	var buf strings.Builder
+	buf.WriteString("(?is)^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			buf.WriteString(".*")
+		case '?':
+			buf.WriteString(".")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	buf.WriteString("$")
+
+	return regexp.MustCompile(buf.String())
+}
+
+// markSecrets marks the Objects in the tree with keys matching the SecretKeys
+// patterns as secret.
+func (o *options) markSecrets(tree meta.Object) meta.Object {
+	if len(o.secretKeys) == 0 {
+		return tree
+	}
+
+	return tree.ToSecret(func(path []string) bool {
+		key := strings.Join(path, o.keyDelimiter)
+		for _, re := range o.secretKeys {
+			if re.MatchString(key) {
+				return true
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretKeys(t *testing.T) {
+	type db struct {
+		User     string
+		Password string `goschtalt:"password,secret"`
+	}
+
+	tests := []struct {
+		description string
+		opts        []Option
+		expected    string
+		secrets     []string
+		plain       []string
+	}{
+		{
+			description: "No patterns.",
+			opts: []Option{
+				AddBuffer("1.json", []byte(`{"db":{"password":"hunter2"}, "token":"hunter2"}`)),
+			},
+			expected: `{"db":{"password":"hunter2"},"token":"hunter2"}`,
+			plain:    []string{"db.password", "token"},
+		}, {
+			description: "Patterns that match.",
+			opts: []Option{
+				AddBuffer("1.json", []byte(`{"db":{"Password":"hunter2", "user":"bob"}, "token":"hunter2"}`)),
+				SecretKeys("*.password"),
+				SecretKeys("*token*"),
+			},
+			expected: `{"db":{"Password":"REDACTED","user":"bob"},"token":"REDACTED"}`,
+			secrets:  []string{"db.Password", "token"},
+			plain:    []string{"db.user"},
+		}, {
+			description: "Patterns that match arrays and use a different delimiter.",
+			opts: []Option{
+				AddBuffer("1.json", []byte(`{"keys":["a", "b"], "password":"hunter2"}`)),
+				SecretKeys("keys/1", "*.password"),
+				SetKeyDelimiter("/"),
+			},
+			expected: `{"keys":["a","REDACTED"],"password":"hunter2"}`,
+			plain:    []string{"password"},
+		}, {
+			description: "Values added later are marked.",
+			opts: []Option{
+				AddBuffer("1.json", []byte(`{"user":"bob"}`)),
+				AddValue("record", "db", map[string]any{"password": "hunter2"}),
+				SecretKeys("db.pass?ord"),
+			},
+			expected: `{"db":{"password":"REDACTED"},"user":"bob"}`,
+			secrets:  []string{"db.password"},
+		}, {
+			description: "Struct fields tagged as secret.",
+			opts: []Option{
+				AddValue("record", "db", db{User: "bob", Password: "hunter2"}),
+			},
+			expected: `{"db":{"User":"bob","password":"REDACTED"}}`,
+			secrets:  []string{"db.password"},
+			plain:    []string{"db.User"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opts := []Option{
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				WithEncoder(&testEncoder{extensions: []string{"json"}}),
+				AutoCompile(),
+			}
+			opts = append(opts, tc.opts...)
+
+			cfg, err := New(opts...)
+			require.NoError(err)
+
+			b, err := cfg.Marshal(RedactSecrets())
+			require.NoError(err)
+			assert.Equal(tc.expected, string(b))
+
+			for _, key := range tc.secrets {
+				var s string
+				err := cfg.Unmarshal(key, &s, FailOnPlainSecrets())
+				assert.ErrorIs(err, ErrSecretExposed, key)
+
+				var got Secret[string]
+				require.NoError(cfg.Unmarshal(key, &got, FailOnPlainSecrets()), key)
+				assert.Equal("hunter2", got.Reveal(), key)
+			}
+
+			for _, key := range tc.plain {
+				var s string
+				assert.NoError(cfg.Unmarshal(key, &s, FailOnPlainSecrets()), key)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"time"
+
+	"github.com/goschtalt/goschtalt/pkg/debug"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// Snapshot is an immutable view of the results of one successful compilation
+// of the configuration.  A Snapshot is safe to use from any number of
+// goroutines and never blocks nor is blocked by the Config it came from, so
+// it is well suited to hot read paths.
+//
+// The options in effect at the time of the compilation are used by the
+// Snapshot.  Changes made via [Config.With]() are only seen in snapshots that
+// are compiled after the change.
+type Snapshot struct {
+	tree       meta.Object
+	compiledAt time.Time
+	hash       []byte
+	records    []string
+	history    []historyStep
+	explain    Explanation
+	remaps     debug.Collect
+	opts       *options
+}
+
+// Snapshot returns the most recently compiled Snapshot of the configuration.
+// If the configuration has not been compiled, an empty Snapshot is returned
+// that returns [ErrNotCompiled] where possible.
+func (c *Config) Snapshot() *Snapshot {
+	if s := c.snapshot.Load(); s != nil {
+		return s
+	}
+
+	return &Snapshot{}
+}
+
+// Unmarshal performs the act of looking up the specified section of the tree
+// and decoding the tree into the result.  Additional options can be specified
+// to adjust the behavior.
+//
+// To read the entire configuration tree, use goschtalt.Root [Root] instead of
+// "" for more clarity.
+//
+// Valid Option Types:
+//   - [GlobalOption]
+//   - [UnmarshalOption]
+//   - [UnmarshalValueOption]
+func (s *Snapshot) Unmarshal(key string, result any, opts ...UnmarshalOption) error {
+	if s.compiledAt.Equal(time.Time{}) {
+		return ErrNotCompiled
+	}
+
+	err := unmarshal(s.opts, key, result, s.tree, opts...)
+	s.opts.observers.OnUnmarshal(key, err)
+
+	return err
+}
+
+// Marshal renders the into the format specified ('json', 'yaml' or other
+// extensions the Codecs provide and if adding comments should be attempted.
+// If a format does not support comments, an error is returned.  The result of
+// the call is a slice of bytes with the information rendered into it.
+//
+// Valid Option Types:
+//   - [GlobalOption]
+//   - [MarshalOption]
+func (s *Snapshot) Marshal(opts ...MarshalOption) ([]byte, error) {
+	if s.compiledAt.Equal(time.Time{}) {
+		return nil, ErrNotCompiled
+	}
+
+	return marshal(s.opts, s.tree, opts...)
+}
+
+// CompiledAt returns when the configuration was compiled.
+func (s *Snapshot) CompiledAt() time.Time {
+	return s.compiledAt
+}
+
+// Hash returns the hash of the configuration; even if the configuration is
+// empty.  SetHasher() needs to be set to get a useful (non-empty) value.
+func (s *Snapshot) Hash() []byte {
+	return s.hash
+}
+
+// Records returns the ordered list of the names of the records that were
+// merged to produce the configuration.
+func (s *Snapshot) Records() []string {
+	if s.records == nil {
+		return nil
+	}
+
+	return append([]string{}, s.records...)
+}
+
+// Explain returns a human focused explanation of how the configuration in this
+// Snapshot was arrived at.  The key remapping included is what was collected up
+// to when the Snapshot was compiled.
+func (s *Snapshot) Explain() Explanation {
+	rv := s.explain.clone()
+	rv.Keyremapping = debug.Collect{
+		Mapping: make(map[string]string, len(s.remaps.Mapping)),
+	}
+	for from, to := range s.remaps.Mapping {
+		rv.Keyremapping.Mapping[from] = to
+	}
+
+	return rv
+}
+
+// GetTree returns a copy of the compiled tree.  This is useful for debugging
+// what the configuration tree looks like with a tool like k0kubun/pp.
+//
+// The value returned is a deep clone & has nothing to do with the original
+// that still resides inside the Snapshot.
+func (s *Snapshot) GetTree() meta.Object {
+	return s.tree.Clone()
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	type db struct {
+		Host string
+		Port int
+	}
+
+	tests := []struct {
+		description string
+		opts        []Option
+		notCompiled bool
+		expect      db
+		records     []string
+		hash        []byte
+	}{
+		{
+			description: "Not compiled.",
+			opts:        []Option{AutoCompile(false)},
+			notCompiled: true,
+		}, {
+			description: "A simple configuration.",
+			opts: []Option{
+				AddValue("one", "db", map[string]any{"Host": "alpha", "Port": 1}),
+				AddValue("two", "db.Port", 2),
+				WithEncoder(&testEncoder{extensions: []string{"json"}}),
+				SetHasher(HasherFunc(func(any) ([]byte, error) {
+					return []byte{0x01}, nil
+				})),
+			},
+			expect:  db{Host: "alpha", Port: 2},
+			records: []string{"one", "two"},
+			hash:    []byte{0x01},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(tc.opts...)
+			require.NoError(err)
+			require.NotNil(cfg)
+
+			snap := cfg.Snapshot()
+			require.NotNil(snap)
+
+			var got db
+			err = snap.Unmarshal("db", &got)
+			_, merr := snap.Marshal()
+
+			if tc.notCompiled {
+				assert.ErrorIs(err, ErrNotCompiled)
+				assert.ErrorIs(merr, ErrNotCompiled)
+				assert.Equal(time.Time{}, snap.CompiledAt())
+				assert.Empty(snap.Records())
+				assert.Empty(snap.Hash())
+				assert.True(snap.GetTree().IsEmpty())
+				assert.Empty(snap.Explain().Records)
+				return
+			}
+
+			require.NoError(err)
+			require.NoError(merr)
+			assert.Equal(tc.expect, got)
+			assert.Equal(tc.records, snap.Records())
+			assert.Equal(tc.hash, snap.Hash())
+			assert.Equal(cfg.CompiledAt(), snap.CompiledAt())
+
+			explain := snap.Explain()
+			require.Equal(len(tc.records), len(explain.Records))
+			for i := range tc.records {
+				assert.Equal(tc.records[i], explain.Records[i].Name)
+			}
+			for _, opt := range explain.Options {
+				assert.NotContains(opt, "keymapCollector")
+			}
+
+			// The snapshot must not change when the configuration does.
+			require.NoError(cfg.With(AddValue("three", "db.Host", "beta")))
+			assert.Equal(explain, snap.Explain())
+
+			got = db{}
+			require.NoError(snap.Unmarshal("db", &got))
+			assert.Equal(tc.expect, got)
+			assert.Equal(tc.records, snap.Records())
+
+			got = db{}
+			require.NoError(cfg.Snapshot().Unmarshal("db", &got))
+			assert.Equal("beta", got.Host)
+		})
+	}
+}
+
+func TestSnapshotConcurrency(t *testing.T) {
+	require := require.New(t)
+
+	cfg, err := New(
+		AddValue("one", "db", map[string]any{"host": "alpha"}),
+	)
+	require.NoError(err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_, err := Unmarshal[map[string]string](cfg, "db")
+				assert.NoError(t, err)
+				_ = cfg.Explain()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				assert.NoError(t, cfg.Compile())
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSnapshotConcurrentOptions(t *testing.T) {
+	require := require.New(t)
+
+	cfg, err := New(
+		AddValue("one", "db", map[string]any{"host": "alpha"}),
+		WithEncoder(&testEncoder{extensions: []string{"json"}}),
+
+		// Leave spare capacity in the shared default options.
+		DefaultUnmarshalOptions(Optional()),
+		DefaultUnmarshalOptions(Required()),
+		DefaultMarshalOptions(RedactSecrets()),
+		DefaultMarshalOptions(RedactSecrets()),
+		DefaultMarshalOptions(RedactSecrets()),
+	)
+	require.NoError(err)
+
+	snap := cfg.Snapshot()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				var got map[string]string
+				err := snap.Unmarshal("db", &got,
+					WithValidator(ValidatorFunc(func(a any) error {
+						if a != &got {
+							return errors.New("another caller's validator was used")
+						}
+						return nil
+					})),
+				)
+				assert.NoError(t, err)
+
+				_, err = snap.Marshal(FormatAs("json"))
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
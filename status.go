@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import "time"
+
+// Status describes the results of the most recent compilations of the
+// configuration.
+type Status struct {
+	// LastSuccess is the start time of the most recent successful compile.
+	LastSuccess time.Time
+
+	// LastSuccessHash is the hash of the most recent successful compile.
+	LastSuccessHash []byte
+
+	// LastAttempt is the start time of the most recent compile, successful or
+	// not.
+	LastAttempt time.Time
+
+	// LastError is the error from the most recent compile or nil if it was
+	// successful.
+	LastError error
+
+	// LastFailure is the start time of the most recent failed compile, even if
+	// later compiles were successful.  It is the zero time if no compile has
+	// failed.
+	LastFailure time.Time
+
+	// LastFailureError is the error from the most recent failed compile, even
+	// if later compiles were successful.
+	LastFailureError error
+
+	// Stale is true when the most recent compile failed and the configuration
+	// in use is from an earlier successful compile.  When [FailClosed] is
+	// in effect the earlier configuration is discarded instead, so Stale is
+	// never true.
+	Stale bool
+}
+
+// Status returns the status of the most recent compilations of the
+// configuration.
+func (c *Config) Status() Status {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	rv := c.status
+	rv.Stale = rv.LastError != nil && c.snapshot.Load() != nil
+
+	return rv
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	testErr := errors.New("test err")
+
+	tests := []struct {
+		description string
+		opts        []Option
+		fail        bool
+		expectStale bool
+		expectClear bool
+	}{
+		{
+			description: "Successful compiles.",
+		}, {
+			description: "A failed compile keeps the last good tree.",
+			fail:        true,
+			expectStale: true,
+		}, {
+			description: "A failed compile explicitly fails open.",
+			opts:        []Option{FailClosed(false)},
+			fail:        true,
+			expectStale: true,
+		}, {
+			description: "A failed compile fails closed.",
+			opts:        []Option{FailClosed()},
+			fail:        true,
+			expectClear: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			var fail bool
+			opts := append(tc.opts,
+				AddValueGetter("record", Root,
+					mockValueGetter{
+						f: func(string, Unmarshaler) (any, error) {
+							if fail {
+								return nil, testErr
+							}
+							return map[string]any{"hello": "world"}, nil
+						},
+					},
+				),
+				SetHasher(HasherFunc(func(any) ([]byte, error) {
+					return []byte{0x01}, nil
+				})),
+			)
+
+			cfg, err := New(opts...)
+			require.NoError(err)
+			require.NotNil(cfg)
+
+			first := cfg.Status()
+			assert.False(first.Stale)
+			assert.NoError(first.LastError)
+			assert.NotEqual(time.Time{}, first.LastSuccess)
+			assert.Equal(first.LastSuccess, first.LastAttempt)
+			assert.Equal(time.Time{}, first.LastFailure)
+			assert.NoError(first.LastFailureError)
+			assert.Equal([]byte{0x01}, first.LastSuccessHash)
+
+			fail = tc.fail
+			err = cfg.Compile()
+
+			got := cfg.Status()
+			assert.Equal(tc.expectStale, got.Stale)
+			assert.True(got.LastAttempt.After(first.LastAttempt))
+			assert.Equal([]byte{0x01}, got.LastSuccessHash)
+
+			val, uerr := Unmarshal[string](cfg, "hello")
+
+			if !tc.fail {
+				assert.NoError(err)
+				assert.NoError(got.LastError)
+				assert.Equal(got.LastAttempt, got.LastSuccess)
+				assert.Equal(time.Time{}, got.LastFailure)
+				assert.NoError(got.LastFailureError)
+				assert.NoError(uerr)
+				assert.Equal("world", val)
+				return
+			}
+
+			assert.ErrorIs(err, testErr)
+			assert.ErrorIs(got.LastError, testErr)
+			assert.Equal(first.LastSuccess, got.LastSuccess)
+			assert.Equal(got.LastAttempt, got.LastFailure)
+			assert.ErrorIs(got.LastFailureError, testErr)
+			failedAt := got.LastFailure
+
+			if tc.expectClear {
+				assert.ErrorIs(uerr, ErrNotCompiled)
+			} else {
+				assert.NoError(uerr)
+				assert.Equal("world", val)
+			}
+
+			// Recover.
+			fail = false
+			require.NoError(cfg.Compile())
+
+			got = cfg.Status()
+			assert.False(got.Stale)
+			assert.NoError(got.LastError)
+			assert.Equal(got.LastAttempt, got.LastSuccess)
+
+			// The failure is still reported after the recovery.
+			assert.Equal(failedAt, got.LastFailure)
+			assert.ErrorIs(got.LastFailureError, testErr)
+
+			val, err = Unmarshal[string](cfg, "hello")
+			assert.NoError(err)
+			assert.Equal("world", val)
+		})
+	}
+}
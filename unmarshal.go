@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-	"time"
 
 	"github.com/goschtalt/goschtalt/internal/mapstructure"
 	"github.com/goschtalt/goschtalt/internal/print"
@@ -76,6 +75,32 @@ func UnmarshalFunc[T any](key string, opts ...UnmarshalOption) func(*Config) (T,
 // and decoding the tree into the result.  Additional options can be specified
 // to adjust the behavior.
 //
+// Values that can't be decoded are reported as [UnmarshalError]s with the key
+// and the origins of the value.
+//
+// Struct fields without a configuration value are filled in with the default
+// from the struct tags if one is provided, either by the "default=" option of
+// the goschtalt tag (which must be the last option) or by the default tag.
+// Defaults are adapted the same as configuration values, so types such as
+// time.Duration need an adapter (see the pkg/adapter package), and count as
+// set for [Strictness].  Missing structs are filled in if any of the fields
+// within have a default, but missing pointers to structs are left nil unless
+// the pointer field has an empty default (`default:""`) of its own.
+//
+//	type Server struct {
+//		Port    int  `goschtalt:"port,default=8080"`
+//		Retries int  `default:"3"`
+//		TLS     *TLS `default:""`
+//	}
+//
+// Once decoded, the structs in the result that implement [Defaulter],
+// [SelfValidator] or [Finalizer] have the methods called, the structs within a
+// struct first.  All the SetDefaults methods are called first, then the
+// ValidateTags and Validate checks, then any [WithValidator] validator, and
+// finally the Finalize methods if everything is valid.  The errors returned by
+// Validate and Finalize are reported as [UnmarshalError]s with the key of the
+// struct.
+//
 // To read the entire configuration tree, use goschtalt.Root [Root] instead of
 // "" for more clarity.
 //
@@ -84,14 +109,7 @@ func UnmarshalFunc[T any](key string, opts ...UnmarshalOption) func(*Config) (T,
 //   - [UnmarshalOption]
 //   - [UnmarshalValueOption]
 func (c *Config) Unmarshal(key string, result any, opts ...UnmarshalOption) error {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	if c.compiledAt.Equal(time.Time{}) {
-		return ErrNotCompiled
-	}
-
-	return c.unmarshal(key, result, c.tree, opts...)
+	return c.Snapshot().Unmarshal(key, result, opts...)
 }
 
 // adapter is a function that maps a value from one form (from) to a different
@@ -136,15 +154,19 @@ func adapterIterator(ff []adapter) func(reflect.Value, reflect.Value) (any, erro
 	}
 }
 
-func (c *Config) unmarshal(key string, result any, tree meta.Object, opts ...UnmarshalOption) error {
+// unmarshal does the work of decoding the tree into the result using the
+// options provided.
+func unmarshal(cfg *options, key string, result any, tree meta.Object, opts ...UnmarshalOption) error {
 	options := unmarshalOptions{
 		decoder: mapstructure.DecoderConfig{
-			Result:  result,
-			TagName: defaultTag,
+			Result:         result,
+			TagName:        defaultTag,
+			DefaultTagName: defaultValueTag,
 		},
 	}
 
-	full := append(c.opts.unmarshalOptions, opts...)
+	// The options are shared by concurrent callers, so they are copied.
+	full := append(append([]UnmarshalOption{}, cfg.unmarshalOptions...), opts...)
 	for _, opt := range full {
 		if opt != nil {
 			err := opt.unmarshalApply(&options)
@@ -154,8 +176,6 @@ func (c *Config) unmarshal(key string, result any, tree meta.Object, opts ...Unm
 		}
 	}
 
-	options.decoder.DecodeHook = adapterIterator(options.adapters)
-
 	options.decoder.MatchName = func(key, field string) bool {
 		encoded := options.mapper(field)
 		if encoded == "-" {
@@ -163,26 +183,61 @@ func (c *Config) unmarshal(key string, result any, tree meta.Object, opts ...Unm
 		}
 		return encoded == key
 	}
+	options.decoder.MapName = options.mapper
+
+	var md *mapstructure.Metadata
+	if options.report != nil {
+		md = &mapstructure.Metadata{}
+		options.decoder.Metadata = md
+	}
+
+	secrets := secretDecoder{
+		config:      options.decoder,
+		adapt:       adapterIterator(options.adapters),
+		failOnPlain: options.failOnPlainSecrets,
+	}
+	options.decoder.DecodeHook = secrets.hook
 
 	obj := tree
+	var path []string
 	if len(key) > 0 {
-		path := strings.Split(key, c.opts.keyDelimiter)
+		path = strings.Split(key, cfg.keyDelimiter)
 
 		var err error
-		obj, err = tree.Fetch(path, c.opts.keyDelimiter)
+		obj, err = tree.Fetch(path, cfg.keyDelimiter)
 		if err != nil {
 			if !options.optional || !errors.Is(err, meta.ErrNotFound) {
 				return err
 			}
 		}
 	}
-	raw := obj.ToRaw()
+	raw := obj.ToRawWithSecrets(wrapSecret)
 
 	decoder, err := mapstructure.NewDecoder(&options.decoder)
 	if err != nil {
 		return err
 	}
 	if err := decoder.Decode(raw); err != nil {
+		return toUnmarshalErrors(err, obj, path, cfg.keyDelimiter)
+	}
+	if md != nil {
+		options.fillReport(md, obj, path, cfg.keyDelimiter)
+	}
+
+	lc := lifecycle{
+		opts:      &options,
+		obj:       obj,
+		path:      path,
+		delimiter: cfg.keyDelimiter,
+	}
+	lc.setDefaults(result)
+
+	if options.validateTags {
+		if err := validateTags(&options, result, obj, path, cfg.keyDelimiter); err != nil {
+			return err
+		}
+	}
+	if err := lc.validate(result); err != nil {
 		return err
 	}
 	if options.validator != nil {
@@ -190,7 +245,7 @@ func (c *Config) unmarshal(key string, result any, tree meta.Object, opts ...Unm
 			return err
 		}
 	}
-	return nil
+	return lc.finalize(result)
 }
 
 // -- UnmarshalOption options follow -------------------------------------------
@@ -205,27 +260,49 @@ type UnmarshalOption interface {
 }
 
 type unmarshalOptions struct {
-	optional  bool
-	mappers   []Mapper
-	adapters  []adapter
-	reporters []KeymapReporter
-	decoder   mapstructure.DecoderConfig
-	validator Validator
+	optional           bool
+	mappers            []Mapper
+	adapters           []adapter
+	reporters          []KeymapReporter
+	decoder            mapstructure.DecoderConfig
+	validator          Validator
+	failOnPlainSecrets bool
+	validateTags       bool
+	report             *UnmarshalMetadata
 }
 
 // mapper is a helper function that applies the mapper function behavior
 // uniformly.
 func (u unmarshalOptions) mapper(s string) string {
-	in := s
+	out := u.name(s)
+	for _, r := range u.reporters {
+		r.Report(s, out)
+	}
+	return out
+}
+
+// fieldKey returns the configuration key for the struct field based on the tag
+// and the field name, and if the field is to be squashed.  A key of "-" means
+// the field is not in the configuration.
+func (u unmarshalOptions) fieldKey(tag, field string) (string, bool) {
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return name, false
+	}
+	if name == "" {
+		name = field
+	}
+
+	return u.name(name), strings.Contains(","+opts+",", ",squash,")
+}
+
+// name applies the mappers without reporting the result.
+func (u unmarshalOptions) name(s string) string {
 	for _, m := range u.mappers {
 		if rv := m.Map(s); rv != "" {
 			s = rv
 		}
 	}
-
-	for _, r := range u.reporters {
-		r.Report(in, s)
-	}
 	return s
 }
 
@@ -37,6 +37,15 @@ func TestUnmarshal(t *testing.T) {
 		Duration time.Duration
 		Time     time.Time
 	}
+	type tls struct {
+		Cert string `default:"cert.pem"`
+	}
+	type withDefaults struct {
+		Name   string
+		Port   int `goschtalt:"port,default=8080"`
+		TLS    *tls
+		Client *tls `default:""`
+	}
 
 	tests := []struct {
 		description string
@@ -249,6 +258,15 @@ func TestUnmarshal(t *testing.T) {
 			expected: simple{
 				Foo: "bar",
 			},
+		}, {
+			description: "Fill in the missing fields from the defaults.",
+			input:       `{"Name":"bar"}`,
+			want:        withDefaults{},
+			expected: withDefaults{
+				Name:   "bar",
+				Port:   8080,
+				Client: &tls{Cert: "cert.pem"},
+			},
 		}, {
 			description: "Convert from camelCase to PascalCase",
 			input:       `{"foo":"bar"}`,
@@ -389,18 +407,21 @@ func TestUnmarshal(t *testing.T) {
 				now = time.Now()
 			}
 
-			c := Config{
-				tree:       tree,
-				compiledAt: now,
-				opts: options{
-					keyDelimiter: ".",
-				},
+			opts := options{
+				keyDelimiter: ".",
 			}
 
 			for _, opt := range tc.defOpts {
-				require.NoError(opt.apply(&c.opts))
+				require.NoError(opt.apply(&opts))
 			}
 
+			var c Config
+			c.snapshot.Store(&Snapshot{
+				tree:       tree,
+				compiledAt: now,
+				opts:       &opts,
+			})
+
 			want := tc.want
 			if tc.nilWanted {
 				err = c.Unmarshal(tc.key, nil, tc.opts...)
@@ -459,17 +480,21 @@ func TestUnmarshalFunc(t *testing.T) {
 			if !tc.skipCompile {
 				err = g.Compile()
 				require.NoError(err)
-				g.tree = meta.Object{
-					Map: map[string]meta.Object{
-						"test": {
-							Map: map[string]meta.Object{
-								"Foo": {
-									Value: "bar",
+				g.snapshot.Store(&Snapshot{
+					compiledAt: g.CompiledAt(),
+					opts:       &g.opts,
+					tree: meta.Object{
+						Map: map[string]meta.Object{
+							"test": {
+								Map: map[string]meta.Object{
+									"Foo": {
+										Value: "bar",
+									},
 								},
 							},
 						},
 					},
-				}
+				})
 			}
 
 			resultingFunc := UnmarshalFunc[sub](tc.key, tc.opts...)
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/mapstructure"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// UnmarshalError describes a value in the configuration that could not be
+// unmarshaled.  Use errors.As to get the details from the error returned by
+// [Config.Unmarshal], or unwrap it (it is joined like errors.Join) to get an
+// UnmarshalError for each of the values.
+type UnmarshalError struct {
+	// Path is the key of the value, joined by the key delimiter.
+	Path string
+
+	// Field is the path of the Go field the value was unmarshaled into, for
+	// example "Server.Hosts[1]".
+	Field string
+
+	// Expected is the Go type of the field.
+	Expected string
+
+	// Value is the value from the configuration, or "REDACTED" if the value is
+	// a secret.
+	Value any
+
+	// Origins are the origins of the value.
+	Origins []meta.Origin
+
+	// Err is the underlying error.  The text of the error may contain the value
+	// even if it is a secret.
+	Err error
+
+	secret bool
+}
+
+func (e *UnmarshalError) Error() string {
+	origins := "unknown"
+	if len(e.Origins) > 0 {
+		origins = meta.Object{Origins: e.Origins}.OriginString()
+	}
+
+	// The decoding errors may include the secret value, but the reason a secret
+	// would be exposed never does.
+	if e.secret && !errors.Is(e.Err, ErrSecretExposed) {
+		return fmt.Sprintf("'%s' from %s: the secret value could not be decoded into '%s'",
+			e.Path, origins, e.Expected)
+	}
+
+	return fmt.Sprintf("'%s' from %s: %v", e.Path, origins, e.Err)
+}
+
+func (e *UnmarshalError) Unwrap() error {
+	return e.Err
+}
+
+// toUnmarshalErrors replaces the errors describing the fields that could not be
+// decoded with UnmarshalErrors, one for each field, joined together.  The obj
+// is the portion of the tree that was decoded and is found at the path.
+func toUnmarshalErrors(err error, obj meta.Object, path []string, delimiter string) error {
+	var list []error
+
+	var walk func(error)
+	walk = func(err error) {
+		if fe, ok := err.(*mapstructure.FieldError); ok {
+			list = append(list, newUnmarshalError(fe, obj, path, delimiter))
+			return
+		}
+
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				walk(e)
+			}
+			return
+		}
+
+		list = append(list, err)
+	}
+	walk(err)
+
+	return errors.Join(list...)
+}
+
+// newUnmarshalError builds the UnmarshalError for the field that could not be
+// decoded.
+func newUnmarshalError(fe *mapstructure.FieldError, obj meta.Object, path []string, delimiter string) *UnmarshalError {
+	rv, secret := describeValue(obj, path, fe.Keys, delimiter)
+	rv.Field = fe.Field
+	rv.Err = fe.Err
+	if fe.Type != nil {
+		rv.Expected = fe.Type.String()
+	}
+
+	// The decoding errors may include the value.
+	rv.secret = secret
+
+	return rv
+}
+
+// describeValue returns an UnmarshalError with the path, origins and value of
+// the Object found at the keys below the obj filled in, and if the value is a
+// secret.  The obj is found at the path in the tree.
+func describeValue(obj meta.Object, path, keys []string, delimiter string) (*UnmarshalError, bool) {
+	all := append(append([]string{}, path...), keys...)
+	rv := UnmarshalError{
+		Path:    strings.Join(all, delimiter),
+		Origins: originsOf(obj, keys),
+	}
+
+	// The value is a secret if it or any of the Objects above it are secret.
+	cur := obj
+	secret := cur.IsSecret()
+	for i := range keys {
+		next, err := cur.Fetch(keys[i:i+1], delimiter)
+		if err != nil {
+			return &rv, secret
+		}
+		cur = next
+		secret = secret || cur.IsSecret()
+	}
+
+	rv.Value = cur.ToRaw()
+	if secret {
+		rv.Value = redacted
+	}
+
+	return &rv, secret
+}
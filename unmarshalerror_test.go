@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goschtalt/goschtalt/internal/mapstructure"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalError(t *testing.T) {
+	type server struct {
+		Port  int
+		Hosts []int
+	}
+
+	type config struct {
+		Server server
+	}
+
+	tests := []struct {
+		description string
+		key         string
+		want        any
+		opts        []Option
+		path        string
+		file        string
+		contains    string
+	}{
+		{
+			description: "A value of the wrong type.",
+			want:        &config{},
+			path:        "Server.Port",
+			file:        "2.json",
+			contains:    "expected type 'int'",
+		}, {
+			description: "A value of the wrong type in an array.",
+			want:        &config{},
+			opts: []Option{
+				AddBuffer("3.json", []byte(`{"Server":{"Port":80}}`)),
+			},
+			path:     "Server.Hosts.1",
+			file:     "1.json",
+			contains: "expected type 'int'",
+		}, {
+			description: "A value of the wrong type below a key.",
+			key:         "Server",
+			want:        &server{},
+			path:        "Server.Port",
+			file:        "2.json",
+			contains:    "expected type 'int'",
+		}, {
+			description: "A value of the wrong type with a different delimiter.",
+			key:         "Server",
+			want:        &server{},
+			opts:        []Option{SetKeyDelimiter("/")},
+			path:        "Server/Port",
+			file:        "2.json",
+			contains:    "expected type 'int'",
+		}, {
+			description: "A value that can't be unmarshaled into the key.",
+			key:         "Server.Port",
+			want:        new(int),
+			path:        "Server.Port",
+			file:        "2.json",
+			contains:    "expected type 'int'",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opts := []Option{
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				AddBuffer("1.json", []byte(`{"Server":{"Port":80, "Hosts":[1, "two"]}}`)),
+				AddBuffer("2.json", []byte(`{"Server":{"Port":"eighty"}}`)),
+				AutoCompile(),
+			}
+			opts = append(opts, tc.opts...)
+
+			cfg, err := New(opts...)
+			require.NoError(err)
+
+			err = cfg.Unmarshal(tc.key, tc.want)
+			require.Error(err)
+			assert.ErrorIs(err, mapstructure.ErrDecoding)
+
+			var ue *UnmarshalError
+			require.True(errors.As(err, &ue))
+			assert.Equal(tc.path, ue.Path)
+			require.NotEmpty(ue.Origins)
+			assert.Equal(tc.file, ue.Origins[0].File)
+			assert.ErrorContains(ue.Err, tc.contains)
+			assert.ErrorContains(err, "'"+tc.path+"' from "+tc.file+":")
+		})
+	}
+}
+
+func TestUnmarshalErrorDefault(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	type server struct {
+		ListenPort int `default:"eighty"`
+	}
+
+	type config struct {
+		Server server
+	}
+
+	cfg, err := New(
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		AddBuffer("1.json", []byte(`{"server":{}}`)),
+		DefaultUnmarshalOptions(Keymap(map[string]string{
+			"Server":     "server",
+			"ListenPort": "listen_port",
+		})),
+		AutoCompile(),
+	)
+	require.NoError(err)
+
+	err = cfg.Unmarshal(Root, &config{})
+	require.Error(err)
+
+	var ue *UnmarshalError
+	require.True(errors.As(err, &ue))
+	assert.Equal("server.listen_port", ue.Path)
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	type db struct {
+		Port     int
+		Password int `goschtalt:"pass"`
+	}
+
+	type config struct {
+		DB    db
+		Hosts []int
+		Name  string
+	}
+
+	cfg, err := New(
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		AddBuffer("1.json", []byte(`{
+			"DB": {"Port":"eighty", "pass((secret))":"hunter2"},
+			"Hosts": [1, "two"],
+			"Name": "ok"
+		}`)),
+		SetKeyDelimiter("/"),
+		AutoCompile(),
+	)
+	require.NoError(err)
+
+	err = cfg.Unmarshal(Root, &config{})
+	require.Error(err)
+	assert.NotContains(err.Error(), "hunter2")
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	require.True(ok)
+
+	got := map[string]*UnmarshalError{}
+	for _, e := range joined.Unwrap() {
+		var ue *UnmarshalError
+		require.True(errors.As(e, &ue))
+		got[ue.Path] = ue
+	}
+	require.Len(got, 3)
+
+	port := got["DB/Port"]
+	require.NotNil(port)
+	assert.Equal("DB.Port", port.Field)
+	assert.Equal("int", port.Expected)
+	assert.Equal("eighty", port.Value)
+	assert.Equal("1.json", port.Origins[0].File)
+	assert.ErrorIs(port, mapstructure.ErrDecoding)
+
+	pass := got["DB/pass"]
+	require.NotNil(pass)
+	assert.Equal("DB.Password", pass.Field)
+	assert.Equal("int", pass.Expected)
+	assert.Equal("REDACTED", pass.Value)
+	assert.NotContains(pass.Error(), "hunter2")
+	assert.Contains(pass.Error(), "'DB/pass' from 1.json:")
+
+	host := got["Hosts/1"]
+	require.NotNil(host)
+	assert.Equal("Hosts[1]", host.Field)
+	assert.Equal("int", host.Expected)
+	assert.Equal("two", host.Value)
+
+	// The reason is kept when a secret would be exposed.
+	var pw string
+	err = cfg.Unmarshal("DB/pass", &pw, FailOnPlainSecrets())
+	require.Error(err)
+	assert.ErrorIs(err, ErrSecretExposed)
+	assert.ErrorContains(err, ErrSecretExposed.Error())
+	assert.NotContains(err.Error(), "hunter2")
+
+	var exposed *UnmarshalError
+	require.True(errors.As(err, &exposed))
+	assert.Equal("REDACTED", exposed.Value)
+
+	// A single problem is still joined so it can be unwrapped the same way.
+	err = cfg.Unmarshal("Hosts", &[]int{})
+	joined, ok = err.(interface{ Unwrap() []error })
+	require.True(ok)
+	require.Len(joined.Unwrap(), 1)
+}
+
+func TestUnmarshalErrorString(t *testing.T) {
+	assert := assert.New(t)
+
+	err := &UnmarshalError{
+		Path:    "a.b",
+		Origins: []meta.Origin{{File: "file.yml", Line: 3, Col: 12}},
+		Err:     ErrDecoding,
+	}
+	assert.Equal("'a.b' from file.yml:3[12]: decoding error", err.Error())
+	assert.ErrorIs(err, ErrDecoding)
+
+	err.Origins = nil
+	assert.Equal("'a.b' from unknown: decoding error", err.Error())
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/mapstructure"
+	"github.com/goschtalt/goschtalt/internal/natsort"
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// UnmarshalMetadata describes how the configuration was used by
+// [Config.Unmarshal].  It is filled in by the [UnmarshalReport] option.
+type UnmarshalMetadata struct {
+	// Used are the configuration values that were unmarshaled into the result.
+	// Values given to a struct, map or slice are included along with the
+	// values within them.
+	Used []KeyMetadata
+
+	// Unused are the configuration values that were ignored because there was
+	// no struct field for them.
+	Unused []KeyMetadata
+
+	// Unset are the struct fields that were not given a value from the
+	// configuration or a default.
+	Unset []KeyMetadata
+}
+
+// KeyMetadata describes a key in the configuration.
+type KeyMetadata struct {
+	// Path is the key, joined by the key delimiter.
+	Path string
+
+	// Field is the path of the Go field, for example "Server.Hosts[1]".  It is
+	// empty for unused keys.
+	Field string
+
+	// Origins are the origins of the value.  Unset fields have the origins of
+	// the closest value above the field in the configuration.
+	Origins []meta.Origin
+}
+
+// UnmarshalReport provides a way to learn which configuration values were
+// used, which were ignored and which struct fields were not set when
+// unmarshaling, without making them errors like [Strictness] does.  The md is
+// filled in each time the option is used and the result is decoded, for
+// example:
+//
+//	var md goschtalt.UnmarshalMetadata
+//	err := cfg.Unmarshal("server", &server, goschtalt.UnmarshalReport(&md))
+//	for _, unused := range md.Unused {
+//		log.Printf("ignored key '%s' from %s", unused.Path,
+//			meta.Object{Origins: unused.Origins}.OriginString())
+//	}
+//
+// Setting the md to nil disables the report.
+//
+// # Default
+//
+// No report is made.
+func UnmarshalReport(md *UnmarshalMetadata) UnmarshalOption {
+	return &unmarshalReportOption{
+		md: md,
+	}
+}
+
+type unmarshalReportOption struct {
+	md *UnmarshalMetadata
+}
+
+func (u unmarshalReportOption) unmarshalApply(opts *unmarshalOptions) error {
+	opts.report = u.md
+	return nil
+}
+
+func (u unmarshalReportOption) String() string {
+	if u.md == nil {
+		return print.P("UnmarshalReport", print.Obj(nil), print.SubOpt())
+	}
+	return print.P("UnmarshalReport", print.Obj(u.md), print.SubOpt())
+}
+
+// fillReport fills in the report based on the metadata from the decoder.  The
+// obj is the portion of the tree that was decoded and is found at the path.
+func (u unmarshalOptions) fillReport(md *mapstructure.Metadata, obj meta.Object, path []string, delimiter string) {
+	used := make([]mapstructure.MetadataPath, 0, len(md.KeyPaths))
+	for _, p := range md.KeyPaths {
+		// Values from the defaults aren't in the configuration.
+		if _, _, found := obj.Lookup(p.Keys); found {
+			used = append(used, p)
+		}
+	}
+
+	unset := make([]mapstructure.MetadataPath, 0, len(md.UnsetPaths))
+	for _, p := range md.UnsetPaths {
+		// The last key is the name of the field, which is mapped to the key
+		// it would have in the configuration.
+		keys := append([]string{}, p.Keys...)
+		keys[len(keys)-1] = u.name(keys[len(keys)-1])
+		unset = append(unset, mapstructure.MetadataPath{Keys: keys, Field: p.Field})
+	}
+
+	*u.report = UnmarshalMetadata{
+		Used:   describeKeys(used, obj, path, delimiter),
+		Unused: describeKeys(md.UnusedPaths, obj, path, delimiter),
+		Unset:  describeKeys(unset, obj, path, delimiter),
+	}
+}
+
+// describeKeys returns the KeyMetadata for each of the paths below the obj,
+// sorted by the keys.
+func describeKeys(list []mapstructure.MetadataPath, obj meta.Object, path []string, delimiter string) []KeyMetadata {
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i].Keys, list[j].Keys
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return natsort.Compare(a[k], b[k])
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	rv := make([]KeyMetadata, 0, len(list))
+	for _, p := range list {
+		all := append(append([]string{}, path...), p.Keys...)
+		rv = append(rv, KeyMetadata{
+			Path:    strings.Join(all, delimiter),
+			Field:   p.Field,
+			Origins: originsOf(obj, p.Keys),
+		})
+	}
+
+	return rv
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalReport(t *testing.T) {
+	type server struct {
+		Port    int
+		Hosts   []string
+		Name    string
+		Timeout string `default:"5s"`
+	}
+
+	type config struct {
+		Servers []server
+		Level   string
+	}
+
+	tests := []struct {
+		description string
+		key         string
+		want        any
+		used        []string
+		unused      []string
+		unset       []string
+		field       string
+		file        string
+	}{
+		{
+			description: "The whole tree.",
+			want:        &config{},
+			used: []string{
+				"Servers", "Servers.0", "Servers.0.Hosts", "Servers.0.Hosts.0",
+				"Servers.0.Port", "Servers.1", "Servers.1.Port",
+			},
+			unused: []string{"Extra", "Servers.1.Other"},
+			unset:  []string{"Level", "Servers.0.Name", "Servers.1.Hosts", "Servers.1.Name"},
+			field:  "Servers[1].Name",
+			file:   "2.json",
+		}, {
+			description: "Part of the tree.",
+			key:         "Servers.1",
+			want:        &server{},
+			used:        []string{"Servers.1.Port"},
+			unused:      []string{"Servers.1.Other"},
+			unset:       []string{"Servers.1.Hosts", "Servers.1.Name"},
+			field:       "Name",
+			file:        "2.json",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				AddBuffer("1.json", []byte(`{"Servers":[{"Port":80, "Hosts":["a"]}], "Extra":1}`)),
+				AddBuffer("2.json", []byte(`{"Servers":[{"Port":443, "Other":"x"}]}`)),
+				AutoCompile(),
+			)
+			require.NoError(err)
+
+			md := UnmarshalMetadata{
+				Used: []KeyMetadata{{Path: "stale"}},
+			}
+			err = cfg.Unmarshal(tc.key, tc.want, UnmarshalReport(&md))
+			require.NoError(err)
+
+			paths := func(list []KeyMetadata) []string {
+				rv := []string{}
+				for _, k := range list {
+					rv = append(rv, k.Path)
+				}
+				return rv
+			}
+			assert.Equal(tc.used, paths(md.Used))
+			assert.Equal(tc.unused, paths(md.Unused))
+			assert.Equal(tc.unset, paths(md.Unset))
+
+			for _, k := range md.Unused {
+				assert.Empty(k.Field)
+				require.NotEmpty(k.Origins)
+			}
+
+			last := md.Unset[len(md.Unset)-1]
+			assert.Equal(tc.field, last.Field)
+			require.NotEmpty(last.Origins)
+			assert.Equal(tc.file, last.Origins[0].File)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/debug"
 )
 
 // UnmarshalValueOption options are options shared between UnmarshalOption and
@@ -171,5 +172,10 @@ func (k keymapReportOption) valueApply(opts *valueOptions) error {
 }
 
 func (k keymapReportOption) String() string {
+	// The collector used for the explanation is internal, so it is described
+	// by what it collects into.
+	if _, ok := k.r.(*keymapCollector); ok {
+		return print.P("KeymapReporter", print.Obj(&debug.Collect{}), print.SubOpt())
+	}
 	return print.P("KeymapReporter", print.Obj(k.r), print.SubOpt())
 }
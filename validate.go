@@ -0,0 +1,415 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/internal/structs"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// validateTag is the go structure tag that provides the validation rules.
+const validateTag = "validate"
+
+// ValidateTags enables the built in validator that checks the unmarshaled
+// structures using the rules found in the validate struct tags.  The rules are
+// separated by commas:
+//
+//   - required - the value must be present in the configuration, or have a
+//     default provided by the struct tags
+//   - nonzero - the value must not be the zero value for the type
+//   - min=N - numbers must be at least N; strings, slices and maps must have a
+//     length of at least N.  For time.Duration values N is a duration.
+//   - max=N - the same as min=N, except N is the maximum
+//   - oneof=a b c - the value must be one of the space separated values
+//   - regexp=expr - strings must match the regular expression.  The
+//     expression ends at the first comma that is followed by the name of a
+//     rule, so it may contain other commas.
+//
+// For example:
+//
+//	type Server struct {
+//		Port  int    `validate:"required,min=1,max=65535"`
+//		Level string `validate:"oneof=debug info warn"`
+//		Name  string `validate:"nonzero,regexp=^[a-z]+$"`
+//	}
+//
+// Nested structs, slices, maps and pointers are checked as well.  Each value
+// that doesn't pass is reported as an [UnmarshalError] wrapping
+// [ErrInvalidValue], joined together.  The validation is done before any
+// [WithValidator] validator is called.
+//
+// The validate bool value is optional & assumed to be `true` if omitted.  The
+// first specified value is used if provided.  A value of `false` disables the
+// option.
+//
+// # Default
+//
+// The validate struct tags are ignored.
+func ValidateTags(validate ...bool) UnmarshalOption {
+	validate = append(validate, true)
+	return validateTagsOption(validate[0])
+}
+
+type validateTagsOption bool
+
+func (v validateTagsOption) unmarshalApply(opts *unmarshalOptions) error {
+	opts.validateTags = bool(v)
+	return nil
+}
+
+func (v validateTagsOption) String() string {
+	return print.P("ValidateTags", print.BoolSilentTrue(bool(v)), print.SubOpt())
+}
+
+// tagValidator checks the values against the rules in the validate tags.
+type tagValidator struct {
+	opts      *unmarshalOptions
+	obj       meta.Object
+	path      []string
+	delimiter string
+	errs      []error
+}
+
+// validateTags returns the problems found with the result, joined together.
+func validateTags(opts *unmarshalOptions, result any, obj meta.Object, path []string, delimiter string) error {
+	v := tagValidator{
+		opts:      opts,
+		obj:       obj,
+		path:      path,
+		delimiter: delimiter,
+	}
+	v.walk(reflect.ValueOf(result), nil, "")
+
+	return errors.Join(v.errs...)
+}
+
+// walk checks the fields of the structs found in the value.  The keys are the
+// path to the value in the configuration and the field is the path of the Go
+// field.
+func (v *tagValidator) walk(val reflect.Value, keys []string, field string) {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Struct:
+		if _, ok := val.Interface().(revealer); ok {
+			return
+		}
+
+		s := structs.New(val.Interface())
+		s.TagName = v.opts.decoder.TagName
+		for _, f := range s.Fields() {
+			if !f.IsExported() {
+				continue
+			}
+			v.walkField(f, keys, field)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			idx := strconv.Itoa(i)
+			v.walk(val.Index(i), append(keys, idx), field+"["+idx+"]")
+		}
+	case reflect.Map:
+		type entry struct {
+			key string
+			val reflect.Value
+		}
+		entries := make([]entry, 0, val.Len())
+		iter := val.MapRange()
+		for iter.Next() {
+			entries = append(entries, entry{
+				key: fmt.Sprint(iter.Key().Interface()),
+				val: iter.Value(),
+			})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].key < entries[j].key
+		})
+		for _, e := range entries {
+			v.walk(e.val, append(keys, e.key), field+"["+e.key+"]")
+		}
+	}
+}
+
+// walkField checks the field against the rules and then checks the values in
+// the field.
+func (v *tagValidator) walkField(f *structs.Field, keys []string, field string) {
+	name, squash := v.opts.fieldKey(f.Tag(v.opts.decoder.TagName), f.Name())
+	if f.IsEmbedded() && squash {
+		v.walk(reflect.ValueOf(f.Value()), keys, field)
+		return
+	}
+	if name == "-" {
+		return
+	}
+
+	keys = append(keys[:len(keys):len(keys)], name)
+	if field != "" {
+		field += "."
+	}
+	field += f.Name()
+
+	val := reflect.ValueOf(f.Value())
+	if err := v.check(f, keys, val); err != nil {
+		rv, secret := describeValue(v.obj, v.path, keys, v.delimiter)
+		rv.Field = field
+		rv.Expected = f.Type().String()
+		if _, ok := f.Value().(revealer); ok || secret {
+			rv.Value = redacted
+		}
+		rv.Err = err
+		v.errs = append(v.errs, rv)
+	}
+
+	v.walk(val, keys, field)
+}
+
+// check returns the first rule the value doesn't pass.
+func (v *tagValidator) check(f *structs.Field, keys []string, val reflect.Value) error {
+	tag := f.Tag(validateTag)
+	if tag == "" {
+		return nil
+	}
+
+	// The value of a secret or pointer is checked.
+	if val.IsValid() {
+		if r, ok := val.Interface().(revealer); ok {
+			val = reflect.ValueOf(r.revealAny())
+		}
+	}
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			val = reflect.Value{}
+			break
+		}
+		val = val.Elem()
+	}
+
+	rules, err := parseRules(tag)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range rules {
+		switch r.name {
+		case "required":
+			err = v.required(f, keys)
+		case "nonzero":
+			if !val.IsValid() || val.IsZero() {
+				err = errors.New("must not be zero")
+			}
+		case "min", "max":
+			err = checkLimit(r.name, r.arg, val)
+		case "oneof":
+			err = checkOneOf(r.arg, val)
+		case "regexp":
+			err = checkRegexp(r, val)
+		}
+
+		if err != nil {
+			if errors.Is(err, ErrInvalidInput) {
+				return err
+			}
+			return fmt.Errorf("%w: %w", ErrInvalidValue, err)
+		}
+	}
+
+	return nil
+}
+
+// validateRule is a single rule from a validate tag.
+type validateRule struct {
+	name string
+	arg  string
+	re   *regexp.Regexp
+}
+
+// validateRules are the names of the supported rules.
+var validateRules = map[string]bool{
+	"required": true,
+	"nonzero":  true,
+	"min":      true,
+	"max":      true,
+	"oneof":    true,
+	"regexp":   true,
+}
+
+// parsedRules caches the rules of each validate tag so the regular expressions
+// are only compiled once.
+var parsedRules sync.Map
+
+// parseRules splits the validate tag into the rules.  A regexp rule continues
+// until a comma that is followed by the name of a rule.
+func parseRules(tag string) ([]validateRule, error) {
+	if cached, found := parsedRules.Load(tag); found {
+		return cached.([]validateRule), nil
+	}
+
+	var rules []validateRule
+	for _, part := range strings.Split(tag, ",") {
+		name, arg, _ := strings.Cut(part, "=")
+		if len(rules) > 0 && rules[len(rules)-1].name == "regexp" && !validateRules[name] {
+			rules[len(rules)-1].arg += "," + part
+			continue
+		}
+		if !validateRules[name] {
+			return nil, fmt.Errorf("%w: unknown validate rule '%s'", ErrInvalidInput, part)
+		}
+		rules = append(rules, validateRule{name: name, arg: arg})
+	}
+
+	for i := range rules {
+		if rules[i].name != "regexp" {
+			continue
+		}
+		re, err := regexp.Compile(rules[i].arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: validate rule 'regexp=%s' is not valid: %w",
+				ErrInvalidInput, rules[i].arg, err)
+		}
+		rules[i].re = re
+	}
+
+	parsedRules.Store(tag, rules)
+	return rules, nil
+}
+
+// required checks that the value is present in the configuration or has a
+// default.
+func (v *tagValidator) required(f *structs.Field, keys []string) error {
+	if _, err := v.obj.Fetch(keys, v.delimiter); err == nil {
+		return nil
+	}
+
+	if strings.Contains(f.Tag(v.opts.decoder.TagName), ",default=") || f.Tag(defaultValueTag) != "" {
+		return nil
+	}
+
+	return errors.New("is required")
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// checkLimit checks the value or length of the value against the min or max
+// limit.
+func checkLimit(name, arg string, val reflect.Value) error {
+	if !val.IsValid() {
+		return nil
+	}
+
+	invalid := fmt.Errorf("%w: validate rule '%s=%s' is not valid for %s",
+		ErrInvalidInput, name, arg, val.Type())
+
+	var cmp int
+	what := "be"
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var limit int64
+		var err error
+		if val.Type() == durationType {
+			var d time.Duration
+			d, err = time.ParseDuration(arg)
+			limit = int64(d)
+		} else {
+			limit, err = strconv.ParseInt(arg, 10, 64)
+		}
+		if err != nil {
+			return invalid
+		}
+		cmp = compare(val.Int(), limit)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		limit, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return invalid
+		}
+		cmp = compare(val.Uint(), limit)
+	case reflect.Float32, reflect.Float64:
+		limit, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return invalid
+		}
+		cmp = compare(val.Float(), limit)
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		limit, err := strconv.Atoi(arg)
+		if err != nil {
+			return invalid
+		}
+		length := val.Len()
+		if val.Kind() == reflect.String {
+			length = utf8.RuneCountInString(val.String())
+		}
+		cmp = compare(length, limit)
+		what = "have a length of"
+	default:
+		return invalid
+	}
+
+	if name == "min" && cmp < 0 {
+		return fmt.Errorf("must %s at least %s", what, arg)
+	}
+	if name == "max" && cmp > 0 {
+		return fmt.Errorf("must %s at most %s", what, arg)
+	}
+	return nil
+}
+
+func compare[T int | int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// checkOneOf checks that the value is one of the space separated values.
+func checkOneOf(arg string, val reflect.Value) error {
+	if !val.IsValid() {
+		return nil
+	}
+
+	options := strings.Fields(arg)
+	got := fmt.Sprint(val.Interface())
+	for _, option := range options {
+		if got == option {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("must be one of [%s]", strings.Join(options, " "))
+}
+
+// checkRegexp checks that the string value matches the regular expression.
+func checkRegexp(r validateRule, val reflect.Value) error {
+	if !val.IsValid() {
+		return nil
+	}
+
+	if val.Kind() != reflect.String {
+		return fmt.Errorf("%w: validate rule 'regexp=%s' is not valid for %s",
+			ErrInvalidInput, r.arg, val.Type())
+	}
+
+	if !r.re.MatchString(val.String()) {
+		return fmt.Errorf("must match '%s'", r.arg)
+	}
+	return nil
+}
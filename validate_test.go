@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTags(t *testing.T) {
+	type limits struct {
+		Port    int            `validate:"required,min=1,max=65535"`
+		Level   string         `validate:"oneof=debug info warn"`
+		Name    string         `validate:"nonzero,regexp=^[a-z]{1,5}$"`
+		Timeout time.Duration  `validate:"min=1s"`
+		Hosts   []string       `validate:"min=1"`
+		Rate    *float64       `validate:"max=1.5"`
+		Pass    Secret[string] `validate:"min=8"`
+	}
+
+	type port struct {
+		Port int `validate:"min=1,max=65535"`
+	}
+
+	type nested struct {
+		Servers []port
+		ByName  map[string]*port
+		Default string `goschtalt:"default,default=x" validate:"required"`
+	}
+
+	tests := []struct {
+		description string
+		key         string
+		want        any
+		input       string
+		opts        []UnmarshalOption
+		expected    map[string]string
+		expectedErr error
+	}{
+		{
+			description: "Everything is valid.",
+			want:        &limits{},
+			input: `{"Port":80, "Level":"info", "Name":"abc", "Timeout":2000000000,
+				"Hosts":["a"], "Rate":1.5, "Pass":"password"}`,
+			opts: []UnmarshalOption{ValidateTags()},
+		}, {
+			description: "Everything is invalid.",
+			want:        &limits{},
+			input: `{"Level":"trace", "Name":"ABC", "Timeout":20,
+				"Hosts":[], "Rate":2, "Pass((secret))":"short"}`,
+			opts: []UnmarshalOption{ValidateTags()},
+			expected: map[string]string{
+				"Port":    "is required",
+				"Level":   "must be one of [debug info warn]",
+				"Name":    "must match '^[a-z]{1,5}$'",
+				"Timeout": "must be at least 1s",
+				"Hosts":   "must have a length of at least 1",
+				"Rate":    "must be at most 1.5",
+				"Pass":    "must have a length of at least 8",
+			},
+		}, {
+			description: "The validation is disabled.",
+			want:        &limits{},
+			input:       `{"Level":"trace"}`,
+			opts:        []UnmarshalOption{ValidateTags(), ValidateTags(false)},
+		}, {
+			description: "Nested values are checked.",
+			key:         "root",
+			want:        &nested{},
+			input: `{"root":{
+				"Servers":[{"Port":80}, {"Port":0}],
+				"ByName":{"a":{"Port":99999}}
+			}}`,
+			opts: []UnmarshalOption{ValidateTags()},
+			expected: map[string]string{
+				"root.Servers.1.Port": "must be at least 1",
+				"root.ByName.a.Port":  "must be at most 65535",
+			},
+		}, {
+			description: "A regexp followed by more rules.",
+			want: &struct {
+				Level string `validate:"required,min=1,max=65535,oneof=debug info warn,regexp=^[a-z]+$,nonzero"`
+			}{},
+			input: `{"Level":"info"}`,
+			opts:  []UnmarshalOption{ValidateTags()},
+		}, {
+			description: "A regexp with commas followed by more rules that fail.",
+			want: &struct {
+				Name string `validate:"regexp=^[a-z]{1,5}(,[a-z]+)?$,min=9"`
+			}{},
+			input: `{"Name":"abc,def"}`,
+			opts:  []UnmarshalOption{ValidateTags()},
+			expected: map[string]string{
+				"Name": "must have a length of at least 9",
+			},
+		}, {
+			description: "An invalid regexp.",
+			want: &struct {
+				Name string `validate:"regexp=^[a-z"`
+			}{},
+			input:       `{"Name":"abc"}`,
+			opts:        []UnmarshalOption{ValidateTags()},
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "An invalid rule.",
+			want: &struct {
+				Port int `validate:"min=a"`
+			}{},
+			input:       `{"Port":1}`,
+			opts:        []UnmarshalOption{ValidateTags()},
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "An unknown rule.",
+			want: &struct {
+				Port int `validate:"unknown"`
+			}{},
+			input:       `{"Port":1}`,
+			opts:        []UnmarshalOption{ValidateTags()},
+			expectedErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				AddBuffer("1.json", []byte(tc.input)),
+				AutoCompile(),
+			)
+			require.NoError(err)
+
+			err = cfg.Unmarshal(tc.key, tc.want, tc.opts...)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			if tc.expected == nil {
+				assert.NoError(err)
+				return
+			}
+
+			require.Error(err)
+			assert.ErrorIs(err, ErrInvalidValue)
+			assert.NotContains(err.Error(), "short")
+
+			joined, ok := err.(interface{ Unwrap() []error })
+			require.True(ok)
+
+			got := map[string]string{}
+			for _, e := range joined.Unwrap() {
+				var ue *UnmarshalError
+				require.True(errors.As(e, &ue))
+				assert.NotEmpty(ue.Field)
+				assert.NotEmpty(ue.Expected)
+				assert.NotEqual("short", ue.Value)
+				got[ue.Path] = strings.TrimPrefix(ue.Err.Error(), ErrInvalidValue.Error()+": ")
+			}
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
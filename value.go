@@ -4,9 +4,11 @@
 package goschtalt
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/goschtalt/goschtalt/internal/print"
 	"github.com/goschtalt/goschtalt/internal/structs"
@@ -34,6 +36,38 @@ func (f ValueGetterFunc) Get(rn string, u Unmarshaler) (any, error) {
 
 var _ ValueGetter = (*ValueGetterFunc)(nil)
 
+// ValueContextGetter provides the methods needed to get the value while
+// honoring the context of the compilation.
+type ValueContextGetter interface {
+	// GetContext is called each time the configuration is compiled.  The ctx
+	// is canceled if the compilation is canceled or the record times out.  The
+	// recordName and an Unmarshaler with the present stage of configuration and
+	// expanded variables are provided to assist.  A data structure (object,
+	// string, int, etc) or an error is returned.
+	GetContext(ctx context.Context, recordName string, u Unmarshaler) (any, error)
+}
+
+// The ValueContextGetterFunc type is an adapter to allow the use of ordinary
+// functions as ValueContextGetters. If f is a function with the appropriate
+// signature, ValueContextGetterFunc(f) is a ValueContextGetter that calls f.
+type ValueContextGetterFunc func(context.Context, string, Unmarshaler) (any, error)
+
+// GetContext calls f(ctx, rn, u)
+func (f ValueContextGetterFunc) GetContext(ctx context.Context, rn string, u Unmarshaler) (any, error) {
+	return f(ctx, rn, u)
+}
+
+var _ ValueContextGetter = (*ValueContextGetterFunc)(nil)
+
+// valueGetterAdapter allows a ValueGetter to be used as a ValueContextGetter.
+type valueGetterAdapter struct {
+	getter ValueGetter
+}
+
+func (v valueGetterAdapter) GetContext(_ context.Context, rn string, u Unmarshaler) (any, error) {
+	return v.getter.Get(rn, u)
+}
+
 // AddValues provides a simple way to set additional configuration values at
 // runtime.
 //
@@ -50,8 +84,8 @@ func AddValue(recordName, key string, val any, opts ...ValueOption) Option {
 		text:       print.P("AddValue", print.String(recordName), print.String(key), print.Obj(val), print.LiteralStringers(opts)),
 		recordName: recordName,
 		key:        key,
-		getter: ValueGetterFunc(
-			func(_ string, _ Unmarshaler) (any, error) {
+		getter: ValueContextGetterFunc(
+			func(_ context.Context, _ string, _ Unmarshaler) (any, error) {
 				return val, nil
 			}),
 		opts: opts,
@@ -72,15 +106,63 @@ func AddValue(recordName, key string, val any, opts ...ValueOption) Option {
 //   - [ValueOption]
 //   - [UnmarshalValueOption]
 func AddValueGetter(recordName, key string, getter ValueGetter, opts ...ValueOption) Option {
+	var ctxGetter ValueContextGetter
+	if getter != nil {
+		ctxGetter = valueGetterAdapter{getter: getter}
+	}
+
 	return &value{
 		text:       print.P("AddValueGetter", print.String(recordName), print.String(key), print.Obj(getter), print.LiteralStringers(opts)),
 		recordName: recordName,
 		key:        key,
+		getter:     ctxGetter,
+		opts:       opts,
+	}
+}
+
+// AddValueContextGetter is the same as [AddValueGetter]() except the getter is
+// provided a context that is canceled when the compilation is canceled or when
+// the record times out.
+//
+// See also: [CompileContext], [Timeout]
+//
+// Valid Option Types:
+//   - [BufferValueOption]
+//   - [GlobalOption]
+//   - [ValueOption]
+//   - [UnmarshalValueOption]
+func AddValueContextGetter(recordName, key string, getter ValueContextGetter, opts ...ValueOption) Option {
+	return &value{
+		text:       print.P("AddValueContextGetter", print.String(recordName), print.String(key), print.Obj(getter), print.LiteralStringers(opts)),
+		recordName: recordName,
+		key:        key,
 		getter:     getter,
 		opts:       opts,
 	}
 }
 
+// DeleteKey provides a simple way to remove a key (and everything below it) that
+// was set by the records sorted before this record at runtime.  This is the
+// same as a record containing the key with the 'delete' command and the
+// 'splice' command on each of the parent keys, so merge strategies don't alter
+// the parents.  If the key's parents are not present nothing is added.  The
+// recordName is used to sort the deletion with the rest of the records.
+//
+// Valid Option Types:
+//   - [BufferValueOption]
+//   - [GlobalOption]
+//   - [ValueOption]
+//   - [UnmarshalValueOption]
+func DeleteKey(recordName, key string, opts ...ValueOption) Option {
+	return &value{
+		text:       print.P("DeleteKey", print.String(recordName), print.String(key), print.LiteralStringers(opts)),
+		recordName: recordName,
+		key:        key,
+		del:        true,
+		opts:       opts,
+	}
+}
+
 // value defines a key and value that is injected into the configuration tree.
 type value struct {
 	text string
@@ -92,7 +174,10 @@ type value struct {
 	key string
 
 	// The getter to use to get the value.
-	getter ValueGetter
+	getter ValueContextGetter
+
+	// If the key should be deleted instead of set.
+	del bool
 
 	// Options that configure how to process the Value provided.
 	// These options are in addition to any default settings set with
@@ -102,7 +187,7 @@ type value struct {
 
 // toTree does the work of converting from a structure of some sort to the
 // normalized object tree goschtalt uses.
-func (v value) toTree(delimiter string, u Unmarshaler, defaultOpts ...ValueOption) (meta.Object, error) {
+func (v value) toTree(ctx context.Context, delimiter string, u Unmarshaler, defaultOpts ...ValueOption) (meta.Object, error) {
 	cfg := valueOptions{
 		tagName: defaultTag,
 	}
@@ -114,7 +199,25 @@ func (v value) toTree(delimiter string, u Unmarshaler, defaultOpts ...ValueOptio
 		}
 	}
 
-	data, err := v.getter.Get(v.recordName, u)
+	if v.del {
+		// The parents are spliced so no merge strategy can alter them.
+		path := strings.Split(v.key, delimiter)
+		for i := range path[:len(path)-1] {
+			path[i] += "((splice))"
+		}
+		path[len(path)-1] += "((delete))"
+		return meta.ObjectFromRawWithOrigin(nil,
+			[]meta.Origin{{File: v.recordName}},
+			path...), nil
+	}
+
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+
+	data, err := v.getter.GetContext(ctx, v.recordName, u)
 	if err != nil {
 		return meta.Object{}, err
 	}
@@ -153,7 +256,12 @@ func (v value) toTree(delimiter string, u Unmarshaler, defaultOpts ...ValueOptio
 		}
 	}
 
-	return tree.FilterNonSerializable(), nil
+	tree = tree.FilterNonSerializable()
+	if cfg.final {
+		tree = tree.ToFinal()
+	}
+
+	return tree, nil
 }
 
 func (v value) apply(opts *options) error {
@@ -161,6 +269,10 @@ func (v value) apply(opts *options) error {
 		return fmt.Errorf("%w: no valid record name provided", ErrInvalidInput)
 	}
 
+	if v.del && len(v.key) == 0 {
+		return fmt.Errorf("%w: the root can not be deleted", ErrInvalidInput)
+	}
+
 	r := record{
 		name: v.recordName,
 		val:  &v,
@@ -211,6 +323,8 @@ type valueOptions struct {
 	reporters             []KeymapReporter
 	failOnNonSerializable bool
 	isDefault             bool
+	final                 bool
+	timeout               time.Duration
 }
 
 // mapper is a simple helper that does the mapping based on the specified
@@ -255,6 +369,33 @@ func (e failOnNonSerializableOption) String() string {
 	return print.P("FailOnNonSerializable", print.BoolSilentTrue(bool(e)), print.SubOpt())
 }
 
+// Final specifies that the values provided are final and can not be changed by
+// any records that are merged later.  Only the values provided are locked, so
+// new keys can still be added to the maps the values are in.  Any later
+// change to a final value causes the compile to fail with an error wrapping
+// meta.ErrFinal that names both records.
+//
+// This is the same as using the ((final)) command on each of the keys.
+//
+// The final bool value is optional & assumed to be `true` if omitted.  The
+// first specified value is used if provided.  A value of `false` disables the
+// option.
+func Final(final ...bool) ValueOption {
+	final = append(final, true)
+	return finalOption(final[0])
+}
+
+type finalOption bool
+
+func (f finalOption) valueApply(opt *valueOptions) error {
+	opt.final = bool(f)
+	return nil
+}
+
+func (f finalOption) String() string {
+	return print.P("Final", print.BoolSilentTrue(bool(f)), print.SubOpt())
+}
+
 // AdapterToCfg provides a method that maps a golang struct object into the
 // configuration form.  It assumed that the converter knows best what that is.
 //
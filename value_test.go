@@ -41,6 +41,17 @@ func TestValueOptions(t *testing.T) {
 			description: "Verify FailOnNonSerializable(false)",
 			opt:         FailOnNonSerializable(false),
 			str:         "FailOnNonSerializable(false)",
+		}, {
+			description: "Verify Final()",
+			opt:         Final(),
+			want: valueOptions{
+				final: true,
+			},
+			str: "Final()",
+		}, {
+			description: "Verify Final(false)",
+			opt:         Final(false),
+			str:         "Final(false)",
 		}, {
 			description: "Verify AsDefault()",
 			opt:         AsDefault(),
@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+)
+
+// Watch polls the files described by the [AddFile](), [AddFiles](), [AddTree](),
+// [AddDir]() and similar options and recompiles the configuration when the
+// contents of any of the files change.  Changes that produce the same hash
+// (see [SetHasher]) as the previous compile are not reported as changes.
+//
+// Watch blocks until the ctx is canceled and returns the context's error.
+//
+// Valid Option Types:
+//   - [WatchOption]
+func (c *Config) Watch(ctx context.Context, opts ...WatchOption) error {
+	wo := watchOptions{
+		interval: 5 * time.Second,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			if err := opt.watchApply(&wo); err != nil {
+				return err
+			}
+		}
+	}
+
+	w := watcher{
+		cfg:  c,
+		opts: wo,
+	}
+
+	// Establish the baseline.  Any errors here will be reported by the first
+	// poll that finds a difference.
+	w.last, _ = c.fingerprint()
+
+	ticker := time.NewTicker(wo.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+// WatchOption provides the means to configure options for the Watch() function.
+type WatchOption interface {
+	fmt.Stringer
+
+	// watchApply applies the options to the Watch function.
+	watchApply(*watchOptions) error
+}
+
+type watchOptions struct {
+	interval time.Duration
+	notify   func(error)
+}
+
+// PollInterval sets how often the files are examined for changes.  The
+// interval must be greater than 0.
+//
+// # Default
+//
+// The files are examined every 5 seconds.
+func PollInterval(d time.Duration) WatchOption {
+	return pollIntervalOption(d)
+}
+
+type pollIntervalOption time.Duration
+
+func (p pollIntervalOption) watchApply(opts *watchOptions) error {
+	if p <= 0 {
+		return fmt.Errorf("%w, PollInterval must be greater than 0", ErrInvalidInput)
+	}
+	opts.interval = time.Duration(p)
+	return nil
+}
+
+func (p pollIntervalOption) String() string {
+	return print.P("PollInterval", print.Literal(time.Duration(p).String()))
+}
+
+// WatchNotify provides a function that is called each time [Watch]() recompiles
+// the configuration because something changed.  The error passed to fn is the
+// result of the compilation (nil if successful) or the error encountered while
+// examining the files.
+//
+// Setting the value to nil disables notifications.
+//
+// # Default
+//
+// No notifications are sent.
+func WatchNotify(fn func(error)) WatchOption {
+	return &watchNotifyOption{
+		fn: fn,
+	}
+}
+
+type watchNotifyOption struct {
+	fn func(error)
+}
+
+func (w watchNotifyOption) watchApply(opts *watchOptions) error {
+	opts.notify = w.fn
+	return nil
+}
+
+func (w watchNotifyOption) String() string {
+	return print.P("WatchNotify", print.Func(w.fn))
+}
+
+// ---- Watch related helper functions follow -----------------------------------
+
+// watcher holds the state of a single Watch() call.
+type watcher struct {
+	cfg  *Config
+	opts watchOptions
+	last []byte
+}
+
+// poll examines the files once and recompiles if they changed.
+func (w *watcher) poll(ctx context.Context) {
+	sum, err := w.cfg.fingerprint()
+	if err != nil {
+		w.notify(err)
+		return
+	}
+
+	if bytes.Equal(sum, w.last) {
+		return
+	}
+	w.last = sum
+
+	before := w.cfg.Hash()
+	err = w.cfg.CompileContext(ctx)
+	if err == nil && len(before) > 0 && bytes.Equal(before, w.cfg.Hash()) {
+		// The files changed, but the resulting configuration did not.
+		return
+	}
+
+	w.notify(err)
+}
+
+func (w *watcher) notify(err error) {
+	if w.opts.notify != nil {
+		w.opts.notify(err)
+	}
+}
+
+// fingerprint calculates a digest of the names and contents of all the files
+// that are described by the filegroups.
+func (c *Config) fingerprint() ([]byte, error) {
+	c.mutex.Lock()
+	groups := c.opts.filegroups
+	c.mutex.Unlock()
+
+	h := sha256.New()
+	for _, grp := range groups {
+		if err := grp.fingerprint(h); err != nil {
+			return nil, err
+		}
+	}
+
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,277 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	iofs "io/fs"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchPoll(t *testing.T) {
+	constHash := HasherFunc(func(any) ([]byte, error) {
+		return []byte{0x01}, nil
+	})
+	contentHash := HasherFunc(func(o any) ([]byte, error) {
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%v", o)))
+		return sum[:], nil
+	})
+
+	tests := []struct {
+		description string
+		hasher      Hasher
+		change      func(fstest.MapFS)
+		expectCalls int
+		expectErr   error
+		expectValue string
+	}{
+		{
+			description: "Nothing changed.",
+			hasher:      contentHash,
+			expectValue: "world",
+		}, {
+			description: "A file changed.",
+			hasher:      contentHash,
+			change: func(fs fstest.MapFS) {
+				fs["conf/1.json"].Data = []byte(`{"hello":"there"}`)
+			},
+			expectCalls: 1,
+			expectValue: "there",
+		}, {
+			description: "A file was added.",
+			hasher:      contentHash,
+			change: func(fs fstest.MapFS) {
+				fs["conf/2.json"] = &fstest.MapFile{
+					Data: []byte(`{"hello":"again"}`),
+					Mode: 0755,
+				}
+			},
+			expectCalls: 1,
+			expectValue: "again",
+		}, {
+			description: "A file changed, but the hash is the same.",
+			hasher:      constHash,
+			change: func(fs fstest.MapFS) {
+				fs["conf/1.json"].Data = []byte(`{"hello":"there"}`)
+			},
+			expectValue: "there",
+		}, {
+			description: "A file changed and the compile fails.",
+			hasher:      contentHash,
+			change: func(fs fstest.MapFS) {
+				fs["conf/1.json"].Data = []byte(`{"hello":`)
+			},
+			expectCalls: 1,
+			expectErr:   ErrDecoding,
+			expectValue: "world",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			fs := fstest.MapFS{
+				"conf/1.json": &fstest.MapFile{
+					Data: []byte(`{"hello":"world"}`),
+					Mode: 0755,
+				},
+			}
+
+			cfg, err := New(
+				AddDir(fs, "conf"),
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				SetHasher(tc.hasher),
+			)
+			require.NoError(err)
+			require.NotNil(cfg)
+
+			var calls int
+			var got error
+			w := watcher{
+				cfg: cfg,
+				opts: watchOptions{
+					notify: func(err error) {
+						calls++
+						got = err
+					},
+				},
+			}
+			w.last, err = cfg.fingerprint()
+			require.NoError(err)
+
+			if tc.change != nil {
+				tc.change(fs)
+			}
+
+			w.poll(context.Background())
+
+			// A second poll must not find anything new.
+			w.poll(context.Background())
+
+			assert.Equal(tc.expectCalls, calls)
+			if tc.expectErr == nil {
+				assert.NoError(got)
+			} else {
+				assert.ErrorIs(got, tc.expectErr)
+			}
+
+			val, err := Unmarshal[string](cfg, "hello")
+			require.NoError(err)
+			assert.Equal(tc.expectValue, val)
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	tests := []struct {
+		description string
+		opts        []WatchOption
+		expectErr   error
+	}{
+		{
+			description: "Runs until canceled.",
+			opts: []WatchOption{
+				PollInterval(time.Millisecond),
+				WatchNotify(nil),
+				nil,
+			},
+			expectErr: context.DeadlineExceeded,
+		}, {
+			description: "An invalid interval.",
+			opts: []WatchOption{
+				PollInterval(0),
+			},
+			expectErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(
+				AddFile(fstest.MapFS{
+					"1.json": &fstest.MapFile{
+						Data: []byte(`{"hello":"world"}`),
+						Mode: 0755,
+					},
+				}, "1.json"),
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+			)
+			require.NoError(err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			err = cfg.Watch(ctx, tc.opts...)
+			assert.ErrorIs(err, tc.expectErr)
+		})
+	}
+}
+
+// lockedFS is a fstest.MapFS that may be changed while Watch is reading it.
+type lockedFS struct {
+	mutex sync.Mutex
+	fs    fstest.MapFS
+	opens int
+}
+
+func (l *lockedFS) Open(name string) (iofs.File, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.opens++
+	return l.fs.Open(name)
+}
+
+func (l *lockedFS) set(name string, data []byte) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.fs[name] = &fstest.MapFile{
+		Data: data,
+		Mode: 0755,
+	}
+}
+
+func (l *lockedFS) openCount() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.opens
+}
+
+func TestWatchNoticesChange(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	fs := lockedFS{
+		fs: fstest.MapFS{
+			"1.json": &fstest.MapFile{
+				Data: []byte(`{"hello":"world"}`),
+				Mode: 0755,
+			},
+		},
+	}
+
+	cfg, err := New(
+		AddFile(&fs, "1.json"),
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+	)
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	notified := make(chan error, 1)
+	done := make(chan error, 1)
+	opens := fs.openCount()
+	go func() {
+		done <- cfg.Watch(ctx,
+			PollInterval(time.Millisecond),
+			WatchNotify(func(err error) {
+				select {
+				case notified <- err:
+				default:
+				}
+			}),
+		)
+	}()
+
+	// Change the file once Watch has read the baseline.
+	require.Eventually(func() bool {
+		return fs.openCount() > opens
+	}, time.Second, time.Millisecond)
+	fs.set("1.json", []byte(`{"hello":"there"}`))
+
+	select {
+	case err := <-notified:
+		assert.NoError(err)
+	case <-ctx.Done():
+		require.Fail("the change was not noticed")
+	}
+
+	val, err := Unmarshal[string](cfg, "hello")
+	require.NoError(err)
+	assert.Equal("there", val)
+
+	cancel()
+	assert.ErrorIs(<-done, context.Canceled)
+}
+
+func TestWatchOptionStrings(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("PollInterval( 1s )", PollInterval(time.Second).String())
+	assert.Equal("WatchNotify( nil )", WatchNotify(nil).String())
+}